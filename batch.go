@@ -0,0 +1,102 @@
+package dateparse
+
+import (
+	"time"
+)
+
+// BatchParser parses many date strings that are expected to share a
+// format, such as a CSV column or the lines of one log file.  The layout
+// found for the first values is tried first for the following ones, which
+// skips format detection, and detection runs again only when it doesn't
+// fit.  A BatchParser is not safe for concurrent use.
+//
+//     b := dateparse.NewBatchParser(nil, nil)
+//     for _, row := range rows {
+//         res, err := b.Parse(row[0])
+//         ...
+//     }
+//     for _, sw := range b.Switches() {
+//         log.Printf("row %d: layout %q -> %q", sw.Index, sw.From, sw.To)
+//     }
+//
+type BatchParser struct {
+	p        *Parser
+	loc      *time.Location
+	layout   string
+	state    DateState
+	n        int
+	hits     int
+	switches []LayoutSwitch
+}
+
+// LayoutSwitch records a change of the layout a BatchParser tries first.
+type LayoutSwitch struct {
+	// Index is the number of the value, counting from 0, that changed the
+	// layout.
+	Index int
+	// From is the previous layout, empty for the first one found.
+	From string
+	// To is the new layout.
+	To string
+}
+
+// NewBatchParser creates a BatchParser using the options of p, or the
+// defaults when p is nil.  loc is used as in ParseIn, nil parses as
+// ParseAny does.
+func NewBatchParser(p *Parser, loc *time.Location) *BatchParser {
+	if p == nil {
+		p = defaultParser
+	}
+	return &BatchParser{p: p, loc: loc}
+}
+
+// Parse parses the next date string.
+func (b *BatchParser) Parse(datestr string) (ParseResult, error) {
+	b.n++
+	if b.layout != "" {
+		if res, err := b.fast(datestr); err == nil {
+			b.hits++
+			return res, nil
+		}
+	}
+	res, err := b.p.parse(datestr, b.loc)
+	if err != nil || res.Layout == "" || res.Layout == b.layout || res.NoDate || res.Ignored != "" {
+		return res, err
+	}
+	// only stick to layouts that give the same result on their own, not
+	// the ones that need a year inferred or a zone name taken out first
+	prev, prevState := b.layout, b.state
+	b.layout, b.state = res.Layout, res.State
+	if fr, err := b.fast(datestr); err != nil || !sameTime(fr.Time, res.Time) {
+		b.layout, b.state = prev, prevState
+		return res, nil
+	}
+	b.switches = append(b.switches, LayoutSwitch{Index: b.n - 1, From: prev, To: res.Layout})
+	return res, nil
+}
+
+// fast parses datestr with the current layout only.
+func (b *BatchParser) fast(datestr string) (ParseResult, error) {
+	return b.p.parseLayout(datestr, b.loc, b.layout, b.state, PrecisionUnknown)
+}
+
+// Layout returns the layout tried first, empty until one was found.
+func (b *BatchParser) Layout() string {
+	return b.layout
+}
+
+// Switches returns the changes of layout so far, the first entry is the
+// layout found first.
+func (b *BatchParser) Switches() []LayoutSwitch {
+	return b.switches
+}
+
+// Stats returns the number of values parsed and how many of them were
+// parsed with the layout tried first.
+func (b *BatchParser) Stats() (parsed, hits int) {
+	return b.n, b.hits
+}
+
+func sameTime(a, b time.Time) bool {
+	return a.Equal(b) && a.Location().String() == b.Location().String()
+}
@@ -0,0 +1,65 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBatchParser(t *testing.T) {
+	b := NewBatchParser(nil, nil)
+	rows := []string{
+		"10/13/2014 01:02:03",
+		"1/2/2006 15:04:05",
+		"12/31/2014 23:59:59",
+		"2014-04-26 17:24:37.3186369",
+		"2014-04-27 08:00:00",
+		"not a date",
+		"4/8/2014 22:05:00",
+	}
+	for _, row := range rows {
+		res, err := b.Parse(row)
+		want, _, wantErr := ParseAny(row)
+		if (err != nil) != (wantErr != nil) {
+			t.Errorf("%q: got error %v want %v", row, err, wantErr)
+			continue
+		}
+		if err == nil && !sameTime(res.Time, want) {
+			t.Errorf("%q: got %v want %v", row, res.Time, want)
+		}
+	}
+
+	switches := b.Switches()
+	want := []LayoutSwitch{
+		{0, "", "01/02/2006 15:04:05"},
+		{1, "01/02/2006 15:04:05", "1/2/2006 15:04:05"},
+		{3, "1/2/2006 15:04:05", "2006-01-02 15:04:05"},
+		{6, "2006-01-02 15:04:05", "1/2/2006 15:04:05"},
+	}
+	if len(switches) != len(want) {
+		t.Fatalf("got switches %+v", switches)
+	}
+	for i, sw := range switches {
+		if sw != want[i] {
+			t.Errorf("switch %d: got %+v want %+v", i, sw, want[i])
+		}
+	}
+	if parsed, hits := b.Stats(); parsed != len(rows) || hits != 2 {
+		t.Errorf("stats %d %d", parsed, hits)
+	}
+
+	// layouts that need the year inferred don't stick
+	p, err := NewParser(ReferenceClock(func() time.Time { return time.Date(2020, 6, 15, 10, 30, 0, 0, time.UTC) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b = NewBatchParser(p, time.UTC)
+	for _, row := range []string{"Jan  2 15:04:05", "Jun  3 15:04:05"} {
+		res, err := b.Parse(row)
+		if err != nil || res.Time.Year() != 2020 {
+			t.Errorf("%q: got %v %v", row, res.Time, err)
+		}
+	}
+	if b.Layout() != "" {
+		t.Errorf("got layout %q", b.Layout())
+	}
+}
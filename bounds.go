@@ -0,0 +1,120 @@
+package dateparse
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrOutOfRange is returned for a date outside the bounds set with Bounds,
+// BoundsFromNow, EpochBounds or EpochMinDigits.  The ParseResult returned
+// with it still holds the time that was read.
+var ErrOutOfRange = errors.New("dateparse: date out of range")
+
+// bounds is an allowed range of times, a zero min or max is open.
+type bounds struct {
+	min, max time.Time
+}
+
+// open reports whether b has neither a min nor a max.
+func (b bounds) open() bool {
+	return b.min.IsZero() && b.max.IsZero()
+}
+
+func (b bounds) check(t time.Time) error {
+	if !b.min.IsZero() && t.Before(b.min) {
+		return fmt.Errorf("%w: %s is before %s", ErrOutOfRange, t.Format(time.RFC3339Nano), b.min.Format(time.RFC3339Nano))
+	}
+	if !b.max.IsZero() && t.After(b.max) {
+		return fmt.Errorf("%w: %s is after %s", ErrOutOfRange, t.Format(time.RFC3339Nano), b.max.Format(time.RFC3339Nano))
+	}
+	return nil
+}
+
+// Bounds rejects dates before min or after max with ErrOutOfRange, a zero
+// min or max leaves that side open.  Inputs that are only a time of day are
+// not checked.
+func Bounds(min, max time.Time) ParserOption {
+	return func(p *Parser) error {
+		if !min.IsZero() && !max.IsZero() && max.Before(min) {
+			return fmt.Errorf("bounds max %s before min %s", max, min)
+		}
+		p.bounds = bounds{min, max}
+		return nil
+	}
+}
+
+// BoundsFromNow rejects dates more than past before or future after the
+// reference clock with ErrOutOfRange, a zero duration leaves that side
+// open.  It is checked on top of Bounds.
+func BoundsFromNow(past, future time.Duration) ParserOption {
+	return func(p *Parser) error {
+		if past < 0 || future < 0 {
+			return fmt.Errorf("negative bounds %s %s", past, future)
+		}
+		p.past, p.future = past, future
+		return nil
+	}
+}
+
+// EpochBounds rejects unix timestamps ("1332151919", "1499979795437")
+// before min or after max, on top of Bounds.  It catches numbers that are
+// not timestamps at all, such as ids, which mostly land far from the dates
+// a system deals with.
+func EpochBounds(min, max time.Time) ParserOption {
+	return func(p *Parser) error {
+		if !min.IsZero() && !max.IsZero() && max.Before(min) {
+			return fmt.Errorf("epoch bounds max %s before min %s", max, min)
+		}
+		p.epochBounds = bounds{min, max}
+		return nil
+	}
+}
+
+// EpochMinDigits rejects unix timestamps with fewer than n digits, "1" is
+// a second after the epoch but hardly ever meant as one.  Ten digits allows
+// seconds since 2001-09-09.
+func EpochMinDigits(n int) ParserOption {
+	return func(p *Parser) error {
+		if n < 0 {
+			return fmt.Errorf("negative epoch digits %d", n)
+		}
+		p.epochDigits = n
+		return nil
+	}
+}
+
+// checkBounds applies the range rules to a successful parse of datestr.
+func (p *parser) checkBounds(datestr string, res *ParseResult) error {
+	if res.NoDate {
+		return nil
+	}
+	if err := p.bounds.check(res.Time); err != nil {
+		return err
+	}
+	if p.past != 0 || p.future != 0 {
+		now := p.now()
+		var rel bounds
+		if p.past != 0 {
+			rel.min = now.Add(-p.past)
+		}
+		if p.future != 0 {
+			rel.max = now.Add(p.future)
+		}
+		if err := rel.check(res.Time); err != nil {
+			return err
+		}
+	}
+	if res.State != StateTimestamp || (p.epochDigits == 0 && p.epochBounds.open()) {
+		return nil
+	}
+	digits := strings.TrimSpace(datestr)
+	if dot := strings.IndexByte(digits, '.'); dot >= 0 {
+		digits = digits[:dot]
+	}
+	if len(digits) < p.epochDigits {
+		return fmt.Errorf("%w: timestamp %s has fewer than %d digits", ErrOutOfRange, datestr, p.epochDigits)
+	}
+	return p.epochBounds.check(res.Time)
+}
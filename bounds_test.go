@@ -0,0 +1,50 @@
+package dateparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBounds(t *testing.T) {
+	now := time.Date(2020, 6, 15, 10, 30, 0, 0, time.UTC)
+	clock := ReferenceClock(func() time.Time { return now })
+	tests := []struct {
+		opt ParserOption
+		in  string
+		ok  bool
+	}{
+		{Bounds(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}), "1999-12-31", false},
+		{Bounds(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}), "2000-01-01", true},
+		{Bounds(time.Time{}, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)), "2031-01-01", false},
+		{BoundsFromNow(0, 24*time.Hour), "2020-06-16 10:00:00", true},
+		{BoundsFromNow(0, 24*time.Hour), "2020-06-16 11:00:00", false},
+		{BoundsFromNow(365*24*time.Hour, 0), "2019-06-01", false},
+		{BoundsFromNow(365*24*time.Hour, 0), "3 days ago", true},
+		{EpochMinDigits(10), "1", false},
+		{EpochMinDigits(10), "1332151919", true},
+		{EpochMinDigits(10), "1332151919.123", true},
+		{EpochBounds(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)), "1499979795437", true},
+		{EpochBounds(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)), "9223372036854775807", false},
+		// epoch rules leave other formats alone
+		{EpochBounds(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}), "1999-12-31", true},
+		// as does Bounds with only a time of day
+		{Bounds(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}), "15:04", true},
+	}
+	for _, tc := range tests {
+		p, err := NewParser(clock, tc.opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := p.ParseAny(tc.in)
+		if tc.ok && err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+		} else if !tc.ok && !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%q: got %v, %v want ErrOutOfRange", tc.in, res.Time, err)
+		}
+	}
+
+	if _, err := NewParser(Bounds(now, now.Add(-time.Hour))); err == nil {
+		t.Error("expected error for max before min")
+	}
+}
@@ -0,0 +1,29 @@
+package dateparse
+
+import (
+	"time"
+)
+
+// ParseBytes is ParseAny for a date held in a []byte, such as a log line
+// read into a reused buffer.  b is converted to a string once, what it
+// returns doesn't refer to b so b can be reused right after.
+func ParseBytes(b []byte) (time.Time, DateState, error) {
+	res, err := defaultParser.parse(string(b), nil)
+	return res.Time, res.State, err
+}
+
+// ParseBytesIn is ParseIn for a date held in a []byte, see ParseBytes.
+func ParseBytesIn(b []byte, loc *time.Location) (time.Time, DateState, error) {
+	res, err := defaultParser.parse(string(b), loc)
+	return res.Time, res.State, err
+}
+
+// ParseBytes is ParseAny for a []byte, see the package level ParseBytes.
+func (p *Parser) ParseBytes(b []byte) (ParseResult, error) {
+	return p.parse(string(b), nil)
+}
+
+// ParseBytesIn is ParseIn for a []byte, see the package level ParseBytes.
+func (p *Parser) ParseBytesIn(b []byte, loc *time.Location) (ParseResult, error) {
+	return p.parse(string(b), loc)
+}
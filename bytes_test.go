@@ -0,0 +1,58 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBytes(t *testing.T) {
+	p, err := NewParser(ReferenceClock(func() time.Time { return time.Date(2020, 6, 15, 10, 30, 0, 0, time.UTC) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ny, _ := time.LoadLocation("America/New_York")
+	for _, datestr := range []string{
+		"2020-06-15 10:30:00",
+		"Mon, 02 Jan 2006 15:04:05 XYZ",
+		"2006-01-02 15:04:05 +0300",
+		"2006-01-02 15:04:05 -07:00 MSK",
+		"Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)",
+		"2020-06-15 10:30:00 Europe/Paris",
+		"1332151919",
+		"3 days ago",
+		"2020-02-02 15:04:05 UTC+8",
+		"2020-02-02 15:04:05 A",
+		"2014-12-16 16:20:00 foo bar",
+		"2006-01-02 15:04:05 +0300 MSKX",
+		"2006-02-31 15:04:05",
+		"not a date",
+	} {
+		for _, loc := range []*time.Location{nil, ny} {
+			want, wantErr := p.ParseIn(datestr, loc)
+			buf := []byte(datestr)
+			got, err := p.ParseBytesIn(buf, loc)
+			for i := range buf {
+				buf[i] = '#'
+			}
+			if (err == nil) != (wantErr == nil) {
+				t.Errorf("%q: got err %v want %v", datestr, err, wantErr)
+				continue
+			}
+			if err != nil {
+				if err.Error() != wantErr.Error() {
+					t.Errorf("%q: got err %q want %q", datestr, err, wantErr)
+				}
+				continue
+			}
+			if !got.Time.Equal(want.Time) || got.Time.String() != want.Time.String() || got.State != want.State ||
+				got.Layout != want.Layout || got.Ignored != want.Ignored {
+				t.Errorf("%q: got %+v want %+v", datestr, got, want)
+			}
+		}
+	}
+
+	tm, state, err := ParseBytes([]byte("2009-08-12T22:15:09Z"))
+	if err != nil || state != StateDigitDashTZ || !tm.Equal(time.Date(2009, 8, 12, 22, 15, 9, 0, time.UTC)) {
+		t.Errorf("got %v %v %v", tm, state, err)
+	}
+}
@@ -0,0 +1,208 @@
+package dateparse
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxShape is the length of the longest date string LayoutCache caches.
+const maxShape = 48
+
+// shape is the pattern of a date string: words with lower case letters
+// become 'a's, the rest of the letters and the separators are kept, so zone
+// names and AM/PM stay apart, and the digits of a number become the range
+// of its value, which is what picks the layout among those of the same
+// pattern: '0' for zero, '1' up to 12 and '2' above.  The fields of a time,
+// next to a ':', are '0' whatever their value.  "2020-06-15 10:30:00 UTC"
+// is "2222-11-22 00:00:00 UTC", "Jun 15 10:30:00" is "aaa 22 00:00:00" and
+// "08/21/71" is "11/22/22", while "06/01/02", read with another layout, is
+// "11/11/11".
+type shape struct {
+	n int
+	b [maxShape]byte
+}
+
+// key is the shape as a map key, string(s.key()) doesn't allocate in a
+// map index.
+func (s *shape) key() []byte { return s.b[:s.n] }
+
+// shapeOf sets s to the shape of datestr, false when it is too long.
+func shapeOf(s *shape, datestr string) bool {
+	if len(datestr) > maxShape {
+		return false
+	}
+	s.n = len(datestr)
+	b := s.b[:len(datestr)]
+	for i := 0; i < len(b); {
+		c, j := datestr[i], i+1
+		switch byteKinds[c] {
+		case tokDigits:
+			v := int(c - '0')
+			for ; j < len(b) && isDigit(datestr[j]); j++ {
+				if v <= 12 {
+					v = v*10 + int(datestr[j]-'0')
+				}
+			}
+			r := byte('2')
+			switch {
+			case v == 0 || i > 0 && datestr[i-1] == ':' || j < len(b) && datestr[j] == ':':
+				r = '0'
+			case v <= 12:
+				r = '1'
+			}
+			for k := i; k < j; k++ {
+				b[k] = r
+			}
+		case tokAlpha:
+			lower := 'a' <= c && c <= 'z'
+			for ; j < len(b) && byteKinds[datestr[j]] == tokAlpha; j++ {
+				lower = lower || ('a' <= datestr[j] && datestr[j] <= 'z')
+			}
+			if !lower {
+				copy(b[i:j], datestr[i:j])
+				break
+			}
+			for k := i; k < j; k++ {
+				b[k] = 'a'
+			}
+		default:
+			b[i] = c
+		}
+		i = j
+	}
+	return true
+}
+
+// layoutCache is a least recently used cache from shape to layout.
+type layoutCache struct {
+	// hits and misses come first for the alignment of 64 bit atomics
+	hits, misses uint64
+
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type cacheEntry struct {
+	shape string
+	// layout is the layout format detection picked for the shape, the
+	// precision follows from it and the shape
+	layout    string
+	state     DateState
+	precision Precision
+}
+
+// CacheStats are the counters of a LayoutCache.
+type CacheStats struct {
+	// Hits is the number of parses done with the cached layout alone,
+	// without a scan.
+	Hits uint64
+	// Misses is the number of parses that went through format detection.
+	Misses uint64
+	// Len is the number of cached shapes.
+	Len int
+}
+
+// LayoutCache remembers the layout format detection picked for up to size
+// shapes of date strings, the pattern of their letters and separators and
+// the range of their date numbers, and parses the following date strings
+// of the same shape with that layout alone, without the scan and without
+// the layouts detection tried before it.  The range of the numbers keeps
+// apart the strings one pattern reads differently: "06/01/02" is read as
+// 06/01/02 but "08/21/71", a month can't be 21, as 01/02/06.  When the
+// cached layout doesn't fit the full parse is done, see Parser.CacheStats
+// for the hit rate.
+//
+// Parsers with BeforeBuiltin custom formats don't use the cache, those
+// are tried first on every date string.
+func LayoutCache(size int) ParserOption {
+	return func(p *Parser) error {
+		if size < 1 {
+			return fmt.Errorf("layout cache size %d less than 1", size)
+		}
+		p.cache = &layoutCache{size: size, entries: make(map[string]*list.Element), lru: list.New()}
+		return nil
+	}
+}
+
+// CacheStats returns the counters of the LayoutCache, zero without one.
+func (p *Parser) CacheStats() CacheStats {
+	c := p.cache
+	if c == nil {
+		return CacheStats{}
+	}
+	c.mu.Lock()
+	n := c.lru.Len()
+	c.mu.Unlock()
+	return CacheStats{Hits: atomic.LoadUint64(&c.hits), Misses: atomic.LoadUint64(&c.misses), Len: n}
+}
+
+func (c *layoutCache) get(s *shape) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[string(s.key())]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	c.lru.MoveToFront(e)
+	return *e.Value.(*cacheEntry), true
+}
+
+func (c *layoutCache) put(s *shape, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[string(s.key())]; ok {
+		entry.shape = e.Value.(*cacheEntry).shape
+		*e.Value.(*cacheEntry) = entry
+		c.lru.MoveToFront(e)
+		return
+	}
+	entry.shape = string(s.key())
+	c.entries[entry.shape] = c.lru.PushFront(&entry)
+	if c.lru.Len() > c.size {
+		last := c.lru.Back()
+		c.lru.Remove(last)
+		delete(c.entries, last.Value.(*cacheEntry).shape)
+	}
+}
+
+// parseCached is parse with the LayoutCache.
+func (p *Parser) parseCached(datestr string, loc *time.Location) (ParseResult, error) {
+	var s shape
+	if !shapeOf(&s, datestr) || p.hasEarlyCustom() {
+		return p.parseFull(datestr, loc)
+	}
+	if e, ok := p.cache.get(&s); ok {
+		if res, err := p.parseLayout(datestr, loc, e.layout, e.state, e.precision); err == nil {
+			atomic.AddUint64(&p.cache.hits, 1)
+			return res, nil
+		}
+	}
+	atomic.AddUint64(&p.cache.misses, 1)
+	res, err := p.parseFull(datestr, loc)
+	if err != nil || res.Layout == "" || res.NoDate || res.Ignored != "" {
+		return res, err
+	}
+	// as in BatchParser, only layouts that read datestr on their own
+	if lr, err := p.parseLayout(datestr, loc, res.Layout, res.State, PrecisionUnknown); err == nil && sameTime(lr.Time, res.Time) {
+		p.cache.put(&s, cacheEntry{layout: res.Layout, state: res.State, precision: res.Precision})
+	}
+	return res, nil
+}
+
+// hasEarlyCustom reports whether there are BeforeBuiltin custom formats.
+func (p *Parser) hasEarlyCustom() bool {
+	if p.families&FamilyCustom == 0 {
+		return false
+	}
+	for _, f := range p.custom {
+		if f.prio == BeforeBuiltin {
+			return true
+		}
+	}
+	return atomic.LoadInt32(&customEarly) > 0
+}
@@ -0,0 +1,95 @@
+package dateparse
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLayoutCache(t *testing.T) {
+	p, err := NewParser(LayoutCache(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, datestr := range []string{"2020-06-15 10:30:00", "2021-02-13 03:04:05", "Jun 15, 2020", "2019-12-31 23:59:59"} {
+		if _, err := p.ParseAny(datestr); err != nil {
+			t.Fatalf("%q: %v", datestr, err)
+		}
+	}
+	if got, want := p.CacheStats(), (CacheStats{Hits: 2, Misses: 2, Len: 2}); got != want {
+		t.Errorf("got %+v want %+v", got, want)
+	}
+	// a third shape evicts the least recently used one
+	p.ParseAny("1332151919")
+	p.ParseAny("2020/06/15")
+	p.ParseAny("Jun 16, 2020")
+	if got, want := p.CacheStats(), (CacheStats{Hits: 2, Misses: 5, Len: 2}); got != want {
+		t.Errorf("got %+v want %+v", got, want)
+	}
+
+	if _, err := NewParser(LayoutCache(0)); err == nil {
+		t.Error("expected error for size 0")
+	}
+	if (CacheStats{}) != defaultParser.CacheStats() {
+		t.Error("expected no stats without a cache")
+	}
+}
+
+func TestLayoutCacheSameResults(t *testing.T) {
+	p, err := NewParser(LayoutCache(64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dates := append(append([]string{}, testDates...), isoDates...)
+	dates = append(dates, "03/02/2020", "13/02/2020", "2014-12-16 06:20:00 UTC", "2014-12-16 06:20:00 EST",
+		"2014-12-16 06:20:00 GMT", "2014-12-16 06:20:00 PST", "May 8, 2009 5:57:51 PM", "May 8, 2009 5:57:51 AM")
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for round := 0; round < 3; round++ {
+				for _, datestr := range dates {
+					want, wantErr := defaultParser.ParseAny(datestr)
+					got, err := p.ParseAny(datestr)
+					if (err == nil) != (wantErr == nil) || !sameTime(got.Time, want.Time) || got.State != want.State || got.Layout != want.Layout {
+						t.Errorf("%q: got %+v %v want %+v %v", datestr, got, err, want, wantErr)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if st := p.CacheStats(); st.Hits == 0 {
+		t.Errorf("expected cache hits, got %+v", st)
+	}
+}
+
+func TestLayoutCacheSameShape(t *testing.T) {
+	// the first date string of each pair leaves a layout in the cache that
+	// the second, of the same pattern of digits, letters and separators,
+	// must not be read with
+	pairs := [][2]string{
+		{"08/21/71", "06/01/02"},
+		{"06/01/02", "08/21/71"},
+		{"03/02/2020", "13/02/2020"},
+		{"2015-02-18 00:12:00 +0100 GMT", "2015-02-18 00:12:00 +0000 GMT"},
+		{"2015-02-18 00:12:00 +0000 GMT", "2015-02-18 00:12:00 +0100 GMT"},
+		{"2015-02-18 00:12:00 -0500 EST", "2015-02-18 00:12:00 +0000 GMT"},
+		{"2015-02-18 00:12:00 +0000 GMT", "2015-02-18 00:12:00 +0000 UTC"},
+		{"2014-12-16 06:20:00 EST", "2014-12-16 06:20:00 GMT"},
+	}
+	for _, pair := range pairs {
+		p, err := NewParser(LayoutCache(8))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.ParseAny(pair[0]); err != nil {
+			t.Fatalf("%q: %v", pair[0], err)
+		}
+		want, wantErr := defaultParser.ParseAny(pair[1])
+		got, err := p.ParseAny(pair[1])
+		if (err == nil) != (wantErr == nil) || got.Time.String() != want.Time.String() || got.Layout != want.Layout {
+			t.Errorf("%q after %q: got %s %q %v want %s %q %v", pair[1], pair[0], got.Time, got.Layout, err, want.Time, want.Layout, wantErr)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package dateparse
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Matcher parses a date format that the built in formats don't cover.  It
+// returns false when datestr is not in its format.  loc is the location of
+// ParseIn and ParseLocal, nil for ParseAny which like time.Parse means UTC
+// unless datestr says otherwise.
+type Matcher func(datestr string, loc *time.Location) (time.Time, bool)
+
+// Priority orders custom formats relative to the built in ones.
+type Priority int
+
+const (
+	// BeforeBuiltin tries the custom format first, it can take over inputs
+	// the built in formats read differently.
+	BeforeBuiltin Priority = iota
+	// AfterBuiltin tries the custom format only when no built in format
+	// fits.
+	AfterBuiltin
+)
+
+// customFormat is a registered layout or matcher.
+type customFormat struct {
+	prio   Priority
+	layout string
+	match  Matcher
+}
+
+var (
+	customMu      sync.RWMutex
+	customFormats []customFormat
+	// customEarly and customLate count the BeforeBuiltin and AfterBuiltin
+	// customFormats, for LayoutCache and to skip the lock when there are
+	// none
+	customEarly int32
+	customLate  int32
+)
+
+// RegisterLayouts adds time.Parse layouts to every Parser, including the
+// one behind the package level functions.  Dates parsed with them have
+// StateCustom and their layout in ParseResult.Layout.
+//
+//     dateparse.RegisterLayouts(dateparse.BeforeBuiltin, "D2006.01.02-T15h04")
+//
+// It is meant to be called from init, formats registered while other
+// goroutines parse are picked up by their next parse.
+func RegisterLayouts(prio Priority, layouts ...string) error {
+	formats, err := layoutFormats(prio, layouts)
+	if err != nil {
+		return err
+	}
+	customMu.Lock()
+	customFormats = append(customFormats, formats...)
+	customMu.Unlock()
+	atomic.AddInt32(customCount(prio), int32(len(formats)))
+	return nil
+}
+
+// RegisterMatcher adds a Matcher to every Parser, see RegisterLayouts.
+func RegisterMatcher(prio Priority, m Matcher) error {
+	format, err := matcherFormat(prio, m)
+	if err != nil {
+		return err
+	}
+	customMu.Lock()
+	customFormats = append(customFormats, format)
+	customMu.Unlock()
+	atomic.AddInt32(customCount(prio), 1)
+	return nil
+}
+
+// CustomLayouts adds time.Parse layouts to a Parser.  They are tried before
+// the ones added with RegisterLayouts and RegisterMatcher of the same
+// priority.
+func CustomLayouts(prio Priority, layouts ...string) ParserOption {
+	return func(p *Parser) error {
+		formats, err := layoutFormats(prio, layouts)
+		if err != nil {
+			return err
+		}
+		p.custom = append(p.custom, formats...)
+		return nil
+	}
+}
+
+// CustomMatcher adds a Matcher to a Parser, see CustomLayouts.
+func CustomMatcher(prio Priority, m Matcher) ParserOption {
+	return func(p *Parser) error {
+		format, err := matcherFormat(prio, m)
+		if err != nil {
+			return err
+		}
+		p.custom = append(p.custom, format)
+		return nil
+	}
+}
+
+func layoutFormats(prio Priority, layouts []string) ([]customFormat, error) {
+	if err := checkPriority(prio); err != nil {
+		return nil, err
+	}
+	formats := make([]customFormat, 0, len(layouts))
+	for _, layout := range layouts {
+		if layoutPrecision(layout) == PrecisionUnknown {
+			return nil, fmt.Errorf("layout %q has no date or time elements", layout)
+		}
+		formats = append(formats, customFormat{prio: prio, layout: layout})
+	}
+	return formats, nil
+}
+
+func matcherFormat(prio Priority, m Matcher) (customFormat, error) {
+	if m == nil {
+		return customFormat{}, errors.New("nil Matcher")
+	}
+	return customFormat{prio: prio, match: m}, checkPriority(prio)
+}
+
+func checkPriority(prio Priority) error {
+	switch prio {
+	case BeforeBuiltin, AfterBuiltin:
+		return nil
+	}
+	return fmt.Errorf("unknown Priority %d", prio)
+}
+
+// customCount returns the counter of the registered formats of priority
+// prio.
+func customCount(prio Priority) *int32 {
+	if prio == BeforeBuiltin {
+		return &customEarly
+	}
+	return &customLate
+}
+
+// parseCustom tries the custom formats of priority prio, the Parser's own
+// first.
+func (p *parser) parseCustom(datestr string, prio Priority) (time.Time, bool) {
+	if t, ok := p.tryCustom(datestr, prio, p.custom); ok {
+		return t, true
+	}
+	if atomic.LoadInt32(customCount(prio)) == 0 {
+		return time.Time{}, false
+	}
+	customMu.RLock()
+	formats := customFormats
+	customMu.RUnlock()
+	return p.tryCustom(datestr, prio, formats)
+}
+
+func (p *parser) tryCustom(datestr string, prio Priority, formats []customFormat) (time.Time, bool) {
+	// a custom format that doesn't fit leaves no trace
+	layout, policyErr := p.layout, p.policyErr
+	for _, f := range formats {
+		if f.prio != prio {
+			continue
+		}
+		if f.match != nil {
+			if t, ok := f.match(datestr, p.loc); ok {
+				p.layout = ""
+				return t, true
+			}
+			continue
+		}
+		if t, err := p.parse(f.layout, datestr); err == nil {
+			return t, true
+		}
+	}
+	p.layout, p.policyErr = layout, policyErr
+	return time.Time{}, false
+}
@@ -0,0 +1,95 @@
+package dateparse
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// resetCustomFormats drops the formats registered by a test, they would
+// otherwise be tried by every test that runs after it.
+func resetCustomFormats() {
+	customMu.Lock()
+	customFormats = nil
+	customMu.Unlock()
+	atomic.StoreInt32(&customEarly, 0)
+	atomic.StoreInt32(&customLate, 0)
+}
+
+func TestCustomFormats(t *testing.T) {
+	defer resetCustomFormats()
+	if err := RegisterLayouts(BeforeBuiltin, "D2006.01.02-T15h04"); err != nil {
+		t.Fatal(err)
+	}
+	res, state, err := ParseAny("D2020.02.02-T15h04")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := res.String(); got != "2020-02-02 15:04:00 +0000 UTC" || state != StateCustom {
+		t.Errorf("got %s %d", got, state)
+	}
+
+	// day-first before the built in month-first slash dates
+	p, err := NewParser(CustomLayouts(BeforeBuiltin, "02/01/2006"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := p.ParseAny("03/02/2020")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Time.Format("2006-01-02"); got != "2020-02-03" || r.State != StateCustom || r.Layout != "02/01/2006" {
+		t.Errorf("got %s %d %q", got, r.State, r.Layout)
+	}
+	// built in formats still win with AfterBuiltin
+	p, err = NewParser(CustomLayouts(AfterBuiltin, "02/01/2006"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, err := p.ParseAny("03/02/2020"); err != nil || r.Time.Format("2006-01-02") != "2020-03-02" {
+		t.Errorf("got %v %v", r.Time, err)
+	}
+
+	// julian day numbers, JD2458882.5
+	jd := func(datestr string, loc *time.Location) (time.Time, bool) {
+		if !strings.HasPrefix(datestr, "JD") {
+			return time.Time{}, false
+		}
+		days, err := strconv.ParseFloat(datestr[2:], 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Unix(int64((days-2440587.5)*86400), 0).UTC(), true
+	}
+	p, err = NewParser(CustomMatcher(AfterBuiltin, jd))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err = p.ParseAny("JD2458881.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Time.String(); got != "2020-02-02 00:00:00 +0000 UTC" || r.State != StateCustom {
+		t.Errorf("got %s %d", got, r.State)
+	}
+	if _, err := defaultParser.ParseAny("JD2458881.5"); err == nil {
+		t.Error("matcher leaked into the default parser")
+	}
+
+	resetCustomFormats()
+	if _, _, err := ParseAny("D2020.02.02-T15h04"); err == nil {
+		t.Error("registered layout still used after reset")
+	}
+	if p, _ := NewParser(LayoutCache(16)); p.hasEarlyCustom() {
+		t.Error("LayoutCache disabled after reset")
+	}
+
+	if _, err := NewParser(CustomLayouts(BeforeBuiltin, "no elements")); err == nil {
+		t.Error("expected error for layout without elements")
+	}
+	if err := RegisterMatcher(BeforeBuiltin, nil); err == nil {
+		t.Error("expected error for nil matcher")
+	}
+}
@@ -6,16 +6,18 @@ import (
 	"time"
 
 	"github.com/apcera/termtables"
-	"github.com/noaway/dateparse"
+	"github.com/axiaoxin-com/dateparse"
 )
 
 var (
 	timezone = ""
 	datestr  = ""
+	explain  = false
 )
 
 func main() {
 	flag.StringVar(&timezone, "timezone", "", "Timezone aka `America/Los_Angeles` formatted time-zone")
+	flag.BoolVar(&explain, "explain", false, "Print the states and layouts tried while parsing")
 	flag.Parse()
 
 	if len(flag.Args()) == 0 {
@@ -24,12 +26,19 @@ func main() {
 		./dateparse "2009-08-12T22:15:09.99Z" 
 
 		./dateparse --timezone="America/Denver" "2017-07-19 03:21:51+00:00"
+
+		./dateparse --explain "Mon, 02-Jan-06 15:04:05 MST"
 		`)
 		return
 	}
 
 	datestr = flag.Args()[0]
 
+	if explain {
+		fmt.Print(dateparse.Explain(datestr))
+		return
+	}
+
 	var loc *time.Location
 	if timezone != "" {
 		// NOTE:  This is very, very important to understand
@@ -76,16 +85,16 @@ type parser func(datestr string, loc *time.Location) time.Time
 
 func parseLocal(datestr string, loc *time.Location) time.Time {
 	time.Local = loc
-	t, _ := dateparse.ParseLocal(datestr)
+	t, _, _ := dateparse.ParseLocal(datestr)
 	return t
 }
 
 func parseIn(datestr string, loc *time.Location) time.Time {
-	t, _ := dateparse.ParseIn(datestr, loc)
+	t, _, _ := dateparse.ParseIn(datestr, loc)
 	return t
 }
 
 func parseAny(datestr string, loc *time.Location) time.Time {
-	t, _ := dateparse.ParseAny(datestr)
+	t, _, _ := dateparse.ParseAny(datestr)
 	return t
 }
@@ -0,0 +1,137 @@
+package dateparse
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrNonexistentTime is returned for a wall clock time skipped by a
+	// daylight saving change, such as 02:30 on the day clocks go from
+	// 02:00 to 03:00, when the parser was created with DSTGap(GapReject).
+	ErrNonexistentTime = errors.New("dateparse: time does not exist in location")
+	// ErrAmbiguousTime is returned for a wall clock time that happens twice
+	// because of a daylight saving change when the parser was created with
+	// DSTOverlap(OverlapReject).
+	ErrAmbiguousTime = errors.New("dateparse: time is ambiguous in location")
+)
+
+// OverlapPolicy is how a wall clock time that happens twice, when clocks go
+// back, is resolved.
+type OverlapPolicy int
+
+const (
+	// OverlapEarliest uses the first of the two instants, the one before
+	// the clocks went back.  This is the default and what time.Date does.
+	OverlapEarliest OverlapPolicy = iota
+	// OverlapLatest uses the second of the two instants.
+	OverlapLatest
+	// OverlapReject fails the parse with ErrAmbiguousTime.
+	OverlapReject
+)
+
+// GapPolicy is how a wall clock time skipped when clocks go forward is
+// resolved.
+type GapPolicy int
+
+const (
+	// GapShiftForward moves the time forward by the length of the gap, on
+	// a one hour gap at 02:00 02:30 becomes 03:30.  This is the default and
+	// what time.Date does.
+	GapShiftForward GapPolicy = iota
+	// GapReject fails the parse with ErrNonexistentTime.
+	GapReject
+)
+
+// DSTResolution records how a wall clock time affected by a daylight saving
+// change was turned into an instant, see ParseResult.DST.
+type DSTResolution int
+
+const (
+	// DSTNone means the time was not in a gap or an overlap.
+	DSTNone DSTResolution = iota
+	// DSTGapShifted means the time was in a gap and moved forward.
+	DSTGapShifted
+	// DSTOverlapEarliest means the time was in an overlap and the earlier
+	// instant was used.
+	DSTOverlapEarliest
+	// DSTOverlapLatest means the time was in an overlap and the later
+	// instant was used.
+	DSTOverlapLatest
+)
+
+// DSTOverlap sets how times that happen twice when clocks go back are
+// resolved.  It applies to times parsed with ParseIn and ParseLocal, or
+// with an embedded zone name, that carry no offset or zone abbreviation.
+func DSTOverlap(policy OverlapPolicy) ParserOption {
+	return func(p *Parser) error {
+		switch policy {
+		case OverlapEarliest, OverlapLatest, OverlapReject:
+		default:
+			return fmt.Errorf("unknown OverlapPolicy %d", policy)
+		}
+		p.overlap = policy
+		return nil
+	}
+}
+
+// DSTGap sets how times skipped when clocks go forward are resolved, see
+// DSTOverlap for the times it applies to.
+func DSTGap(policy GapPolicy) ParserOption {
+	return func(p *Parser) error {
+		switch policy {
+		case GapShiftForward, GapReject:
+		default:
+			return fmt.Errorf("unknown GapPolicy %d", policy)
+		}
+		p.gap = policy
+		return nil
+	}
+}
+
+// wallClock returns the instant the wall clock time of w, read as UTC,
+// stands for in loc according to the gap and overlap policies.
+func (p *parser) wallClock(w time.Time, loc *time.Location) (time.Time, error) {
+	if loc == time.UTC {
+		return w, nil
+	}
+	secs := w.Unix()
+	// the offsets in effect around the time, a day either side covers
+	// every daylight saving change in the tz database
+	_, before := time.Unix(secs-24*60*60, 0).In(loc).Zone()
+	_, after := time.Unix(secs+24*60*60, 0).In(loc).Zone()
+
+	var valid []int64
+	for _, offset := range []int{before, after} {
+		u := secs - int64(offset)
+		if _, o := time.Unix(u, 0).In(loc).Zone(); o == offset && (len(valid) == 0 || valid[0] != u) {
+			valid = append(valid, u)
+		}
+	}
+
+	nsec := int64(w.Nanosecond())
+	switch len(valid) {
+	case 1:
+		return time.Unix(valid[0], nsec).In(loc), nil
+	case 0:
+		if p.gap == GapReject {
+			return time.Time{}, fmt.Errorf("%w: %s in %s", ErrNonexistentTime, w.Format("2006-01-02 15:04:05"), loc)
+		}
+		p.dst = DSTGapShifted
+		return time.Unix(secs-int64(before), nsec).In(loc), nil
+	}
+	earliest, latest := valid[0], valid[1]
+	if earliest > latest {
+		earliest, latest = latest, earliest
+	}
+	switch p.overlap {
+	case OverlapReject:
+		return time.Time{}, fmt.Errorf("%w: %s in %s", ErrAmbiguousTime, w.Format("2006-01-02 15:04:05"), loc)
+	case OverlapLatest:
+		p.dst = DSTOverlapLatest
+		return time.Unix(latest, nsec).In(loc), nil
+	}
+	p.dst = DSTOverlapEarliest
+	return time.Unix(earliest, nsec).In(loc), nil
+}
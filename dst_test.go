@@ -0,0 +1,59 @@
+package dateparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDSTPolicies(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	const (
+		gap     = "2020-03-08 02:30:00"
+		overlap = "2020-11-01 01:30:00"
+	)
+	tests := []struct {
+		opts []ParserOption
+		in   string
+		out  string
+		dst  DSTResolution
+		err  error
+	}{
+		{nil, "2020-03-08 01:30:00", "2020-03-08 01:30:00 -0500 EST", DSTNone, nil},
+		{nil, gap, "2020-03-08 03:30:00 -0400 EDT", DSTGapShifted, nil},
+		{[]ParserOption{DSTGap(GapReject)}, gap, "", DSTNone, ErrNonexistentTime},
+		{nil, overlap, "2020-11-01 01:30:00 -0400 EDT", DSTOverlapEarliest, nil},
+		{[]ParserOption{DSTOverlap(OverlapLatest)}, overlap, "2020-11-01 01:30:00 -0500 EST", DSTOverlapLatest, nil},
+		{[]ParserOption{DSTOverlap(OverlapReject)}, overlap, "", DSTNone, ErrAmbiguousTime},
+		{[]ParserOption{DSTOverlap(OverlapLatest)}, "November 1st 2020 at 1:30am", "2020-11-01 01:30:00 -0500 EST", DSTOverlapLatest, nil},
+		{[]ParserOption{DSTGap(GapReject)}, "03/08/2020 02:30", "", DSTNone, ErrNonexistentTime},
+		{[]ParserOption{DSTGap(GapReject)}, "Mar 8 2020 02:30:00", "", DSTNone, ErrNonexistentTime},
+		{[]ParserOption{DSTGap(GapReject), ReferenceClock(func() time.Time { return time.Date(2020, 3, 8, 12, 0, 0, 0, ny) })},
+			"02:30", "", DSTNone, ErrNonexistentTime},
+		// an explicit offset says which one was meant
+		{[]ParserOption{DSTOverlap(OverlapReject)}, "2020-11-01 01:30:00 -0500", "2020-11-01 01:30:00 -0500 EST", DSTNone, nil},
+	}
+	for _, tc := range tests {
+		p, err := NewParser(tc.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := p.ParseIn(tc.in, ny)
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) || !res.Time.IsZero() {
+				t.Errorf("%q: got %v %v want %v", tc.in, res.Time, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+		if got := res.Time.String(); got != tc.out || res.DST != tc.dst {
+			t.Errorf("%q: got %s %d want %s %d", tc.in, got, res.DST, tc.out, tc.dst)
+		}
+	}
+}
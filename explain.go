@@ -0,0 +1,134 @@
+package dateparse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Trace records how a date string was parsed: the states the scan went
+// through, the layouts tried and the outcome.  See Explain and TraceHook.
+type Trace struct {
+	// Input is the date string.
+	Input string
+	// Scans are the runs of the state machine, usually one.  A date with
+	// an offset such as "UTC+8" is scanned without it, and again whole when
+	// that fails.
+	Scans []ScanTrace
+	// Attempts are the layouts handed to time.Parse, in order.
+	Attempts []LayoutAttempt
+	// Result and Err are what the parse returned.
+	Result ParseResult
+	Err    error
+}
+
+// ScanTrace is one run of the state machine over a date string.
+type ScanTrace struct {
+	// Input is the part of the date string scanned.
+	Input string
+	// Steps are the transitions, one per byte read.
+	Steps []TraceStep
+	// State is the state the scan ended in.
+	State DateState
+}
+
+// TraceStep is the transition taken on one byte.
+type TraceStep struct {
+	// Pos is the offset of Byte in the scanned string.
+	Pos  int
+	Byte byte
+	From DateState
+	To   DateState
+	// Stop is set on the step that ended the scan, the byte is not read
+	// when From has no transitions.
+	Stop bool
+}
+
+// LayoutAttempt is a layout tried on the date string.
+type LayoutAttempt struct {
+	Layout string
+	// Err is the error of time.Parse or of the Parser's policies, nil when
+	// the layout matched.
+	Err error
+}
+
+// Explain parses datestr as ParseAny does and returns the trace of it,
+// for when a date string fails to parse and it isn't clear why:
+//
+//     fmt.Print(dateparse.Explain("Mon, 02-Jan-06 15:04:05 MST"))
+func Explain(datestr string) Trace {
+	return defaultParser.explain(datestr, nil)
+}
+
+// Explain is Explain with the options of p.  The LayoutCache is not used,
+// the trace is of the full format detection.
+func (p *Parser) Explain(datestr string) Trace {
+	return p.explain(datestr, nil)
+}
+
+// TraceHook calls hook with the trace of every parse, as Explain returns
+// it.  Tracing slows parsing down and bypasses the LayoutCache, it is meant
+// for debugging:
+//
+//     dateparse.TraceHook(func(tr dateparse.Trace) {
+//         if tr.Err != nil {
+//             log.Print(tr)
+//         }
+//     })
+func TraceHook(hook func(Trace)) ParserOption {
+	return func(p *Parser) error {
+		p.traceHook = hook
+		return nil
+	}
+}
+
+func (p *Parser) explain(datestr string, loc *time.Location) Trace {
+	tr := Trace{Input: datestr}
+	ps := parser{Parser: p, loc: loc, strict: p.strict, trace: &tr}
+	tr.Result, tr.Err = ps.run(datestr)
+	return tr
+}
+
+// traceScan starts the trace of a scan of datestr.
+func (p *parser) traceScan(datestr string) {
+	p.trace.Scans = append(p.trace.Scans, ScanTrace{Input: datestr})
+}
+
+// traceStep records a transition of the current scan.
+func (p *parser) traceStep(datestr string, i int, from, to DateState, stop bool) {
+	s := &p.trace.Scans[len(p.trace.Scans)-1]
+	s.Steps = append(s.Steps, TraceStep{Pos: i, Byte: datestr[i], From: from, To: to, Stop: stop})
+	s.State = to
+}
+
+// String prints the trace a line per step, for logs.
+func (tr Trace) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q\n", tr.Input)
+	for _, s := range tr.Scans {
+		if len(tr.Scans) > 1 || s.Input != tr.Input {
+			fmt.Fprintf(&b, "  scan %q\n", s.Input)
+		}
+		for _, st := range s.Steps {
+			fmt.Fprintf(&b, "    %3d %q %s -> %s", st.Pos, []byte{st.Byte}, st.From, st.To)
+			if st.Stop {
+				b.WriteString(" stop")
+			}
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "  state %s\n", s.State)
+	}
+	for _, a := range tr.Attempts {
+		if a.Err != nil {
+			fmt.Fprintf(&b, "  layout %q: %v\n", a.Layout, a.Err)
+		} else {
+			fmt.Fprintf(&b, "  layout %q: ok\n", a.Layout)
+		}
+	}
+	if tr.Err != nil {
+		fmt.Fprintf(&b, "  error: %v\n", tr.Err)
+	} else {
+		fmt.Fprintf(&b, "  result %v, %s\n", tr.Result.Time, tr.Result.State)
+	}
+	return b.String()
+}
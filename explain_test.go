@@ -0,0 +1,102 @@
+package dateparse
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	tr := Explain("Mon, 02-Jan-06 15:04:05 MST")
+	if tr.Err != nil {
+		t.Fatal(tr.Err)
+	}
+	if len(tr.Scans) != 1 {
+		t.Fatalf("got %d scans", len(tr.Scans))
+	}
+	s := tr.Scans[0]
+	if s.State != StateWeekdayAbbrevComma || len(s.Steps) != 8 {
+		t.Errorf("got %s after %d steps", s.State, len(s.Steps))
+	}
+	if last := s.Steps[len(s.Steps)-1]; last != (TraceStep{Pos: 7, Byte: '-', From: StateWeekdayAbbrevComma, To: StateWeekdayAbbrevComma, Stop: true}) {
+		t.Errorf("got last step %+v", last)
+	}
+	if s.Steps[0] != (TraceStep{Pos: 0, Byte: 'M', From: StateStart, To: StateAlpha}) {
+		t.Errorf("got first step %+v", s.Steps[0])
+	}
+	// layouts registered by other tests may come first
+	if n := len(tr.Attempts); n == 0 || tr.Attempts[n-1] != (LayoutAttempt{Layout: "Mon, 02-Jan-06 15:04:05 MST"}) {
+		t.Errorf("got attempts %+v", tr.Attempts)
+	}
+	want, _ := defaultParser.ParseAny(tr.Input)
+	if !sameTime(tr.Result.Time, want.Time) || tr.Result.Layout != want.Layout {
+		t.Errorf("got %+v want %+v", tr.Result, want)
+	}
+
+	// a failure lists every layout tried with its error
+	tr = Explain("13/13/2014 06:55")
+	if tr.Err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(tr.Attempts) < 2 {
+		t.Fatalf("got attempts %+v", tr.Attempts)
+	}
+	for _, a := range tr.Attempts {
+		if a.Err == nil {
+			t.Errorf("layout %q: expected an error", a.Layout)
+		}
+	}
+	out := tr.String()
+	for _, line := range []string{`"13/13/2014 06:55"`, `  0 "1" Start -> Digit`, "  state DigitSlashWSColon\n", "  error: "} {
+		if !strings.Contains(out, line) {
+			t.Errorf("missing %q in\n%s", line, out)
+		}
+	}
+
+	// an offset the layouts don't know is scanned separately
+	tr = Explain("2020-02-02 15:04:05 UTC+8")
+	if tr.Err != nil || len(tr.Scans) != 1 || tr.Scans[0].Input != "2020-02-02 15:04:05" {
+		t.Errorf("got %+v", tr)
+	}
+}
+
+func TestTraceHook(t *testing.T) {
+	var traces []Trace
+	p, err := NewParser(LayoutCache(8), TraceHook(func(tr Trace) {
+		traces = append(traces, tr)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.ParseAny("2020-06-15 10:30:00")
+	p.ParseAny("not a date")
+	if len(traces) != 2 {
+		t.Fatalf("got %d traces", len(traces))
+	}
+	if traces[0].Err != nil || traces[0].Result.Layout != "2006-01-02 15:04:05" {
+		t.Errorf("got %+v", traces[0])
+	}
+	if traces[1].Err == nil {
+		t.Errorf("expected an error in %+v", traces[1])
+	}
+	// the hook bypasses the cache
+	if stats := p.CacheStats(); stats.Len != 0 {
+		t.Errorf("got %+v", stats)
+	}
+
+	// traces of ParseBytes don't refer to the []byte, failures are traced once
+	traces = nil
+	for _, datestr := range []string{"2006-01-02 15:04:05 +9999", "2014-12-16 06:20:00 UTC+8"} {
+		buf := []byte(datestr)
+		p.ParseBytes(buf)
+		for i := range buf {
+			buf[i] = '#'
+		}
+		tr := traces[len(traces)-1]
+		if tr.Input != datestr || tr.Scans[0].Input[:4] != datestr[:4] || tr.String() != p.Explain(datestr).String() {
+			t.Errorf("%q: trace changed with the buffer: %s", datestr, tr)
+		}
+	}
+	if len(traces) != 2 {
+		t.Errorf("got %d traces want 2", len(traces))
+	}
+}
@@ -0,0 +1,158 @@
+package dateparse
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrFamilyNotAllowed is returned for a date string in a format family the
+// parser was told not to accept with AllowFamilies or DenyFamilies.
+var ErrFamilyNotAllowed = errors.New("dateparse: format family not allowed")
+
+// Family is a set of date format families, see AllowFamilies.
+type Family uint
+
+const (
+	// FamilyISO is ISO 8601 and the numeric formats close to it:
+	// 2006-01-02, 2006-01-02T15:04:05Z07:00, 2006-01-02 15:04:05,
+	// 20060102, 2006 and times of day such as 15:04.
+	FamilyISO Family = 1 << iota
+	// FamilyRFC1123 is the weekday first formats of RFC 1123, RFC 850 and
+	// the Unix tools: Mon, 02 Jan 2006 15:04:05 MST, Mon Jan _2 15:04:05 2006.
+	FamilyRFC1123
+	// FamilySlash is numeric dates with slashes: 01/02/2006, 2006/01/02.
+	FamilySlash
+	// FamilyAlpha is dates with month names: Jan 2, 2006, 2 Jan 2006,
+	// March 3rd 2020 at 4pm, syslog and Apache logs.  It also covers the
+	// zones written out after a date of any family, an IANA name outside
+	// RFC 9557 brackets, GMT+8, UTC-03:30 or a military letter, which
+	// ISO 8601 and RFC 3339 don't have: both families must be allowed.
+	FamilyAlpha
+	// FamilyCJK is chinese dates and times: 2006年01月02日, 下午3点.
+	FamilyCJK
+	// FamilyRelative is times relative to now: 3 days ago.
+	FamilyRelative
+	// FamilyEpoch is unix timestamps in seconds, milli-, micro- and
+	// nanoseconds: 1332151919, 1332151919.123.
+	FamilyEpoch
+	// FamilyKeyword is now, noon and midnight.
+	FamilyKeyword
+	// FamilyCustom is the formats added with RegisterLayouts,
+	// RegisterMatcher, CustomLayouts and CustomMatcher.
+	FamilyCustom
+
+	// FamilyAll is every family, the default.
+	FamilyAll = FamilyISO | FamilyRFC1123 | FamilySlash | FamilyAlpha | FamilyCJK |
+		FamilyRelative | FamilyEpoch | FamilyKeyword | FamilyCustom
+)
+
+var familyNames = []string{"ISO", "RFC1123", "Slash", "Alpha", "CJK", "Relative", "Epoch", "Keyword", "Custom"}
+
+func (f Family) String() string {
+	if f == 0 {
+		return "none"
+	}
+	var names []string
+	for i, name := range familyNames {
+		if f&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	if rest := f &^ FamilyAll; rest != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint(rest)))
+	}
+	return strings.Join(names, "|")
+}
+
+// AllowFamilies makes the parser accept only date strings in the given
+// format families, anything else fails with ErrFamilyNotAllowed before it
+// is parsed.  For an API that takes RFC 3339 and unix seconds only:
+//
+//     p, err := dateparse.NewParser(dateparse.AllowFamilies(dateparse.FamilyISO | dateparse.FamilyEpoch))
+//
+func AllowFamilies(f Family) ParserOption {
+	return func(p *Parser) error {
+		p.families = f & FamilyAll
+		return nil
+	}
+}
+
+// DenyFamilies removes format families from the ones accepted, on top of
+// AllowFamilies when both are given.
+func DenyFamilies(f Family) ParserOption {
+	return func(p *Parser) error {
+		p.families &^= f
+		return nil
+	}
+}
+
+// stateFamily returns the family of a date string parseTime found to be in
+// state.  A few states hold more than one family and look at datestr.
+func stateFamily(state DateState, datestr string) Family {
+	switch state {
+	case StateStart:
+		return 0
+	case StateDigit:
+		if len(datestr) == len("2006") || len(datestr) == len("20060102") {
+			return FamilyISO
+		}
+		return FamilyEpoch
+	case StateDigitPeriod, StateTimestamp:
+		return FamilyEpoch
+	case StateDigitDashAlpha, StateDigitSlashAlpha, StateLongForm:
+		return FamilyAlpha
+	case StateDigitSlash, StateDigitSlashWS, StateDigitSlashWSColon, StateDigitSlashWSColonAMPM,
+		StateDigitSlashWSColonColon, StateDigitSlashWSColonColonAMPM:
+		return FamilySlash
+	case StateDigitAlpha:
+		if strings.ContainsAny(datestr, "年月日") {
+			return FamilyCJK
+		}
+		return FamilyAlpha
+	case StateAlpha, StateAlphaWS, StateAlphaWSDigit, StateAlphaWSDigitComma, StateAlphaWSDigitColon,
+		StateAlphaWSDigitDash:
+		return FamilyAlpha
+	case StateAlphaWSAlpha, StateAlphaWSAlphaColon, StateAlphaWSAlphaColonOffset, StateAlphaWSAlphaColonAlpha,
+		StateAlphaWSAlphaColonAlphaOffset, StateAlphaWSAlphaColonAlphaOffsetAlpha,
+		StateWeekdayComma, StateWeekdayCommaOffset, StateWeekdayAbbrevComma, StateWeekdayAbbrevCommaOffset,
+		StateWeekdayAbbrevCommaOffsetZone:
+		return FamilyRFC1123
+	case StateHowLongAgo:
+		return FamilyRelative
+	case StateNow:
+		return FamilyKeyword
+	case StateTimeOfDay:
+		if strings.ContainsAny(datestr, "点时") {
+			return FamilyCJK
+		}
+		return FamilyKeyword
+	case StateCustom:
+		return FamilyCustom
+	}
+	// StateDigitDash..., StateDigitColon
+	return FamilyISO
+}
+
+// checkFamily fails a parse in state, before its layouts are tried, if the
+// parser doesn't accept its family.
+func (p *parser) checkFamily(state DateState, datestr string) error {
+	if p.families == FamilyAll {
+		return nil
+	}
+	f := stateFamily(state, datestr)
+	if f == 0 || p.families&f != 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s is %s", ErrFamilyNotAllowed, datestr, f)
+}
+
+// checkZoneFamily fails the parse of datestr, whose zone run took out
+// before the format was detected because it was written out as a name,
+// unless the parser accepts FamilyAlpha.
+func (p *parser) checkZoneFamily(datestr string) error {
+	if p.families&FamilyAlpha != 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s is %s", ErrFamilyNotAllowed, datestr, FamilyAlpha)
+}
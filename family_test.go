@@ -0,0 +1,72 @@
+package dateparse
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFamilies(t *testing.T) {
+	tests := []struct {
+		in     string
+		family Family
+	}{
+		{"2006-01-02T15:04:05Z", FamilyISO},
+		{"2006-01-02 15:04:05", FamilyISO},
+		{"2014-04-26 05:24:37 PM", FamilyISO},
+		{"20140601", FamilyISO},
+		{"15:04", FamilyISO},
+		{"Mon, 02 Jan 2006 15:04:05 MST", FamilyRFC1123},
+		{"Monday, 02-Jan-06 15:04:05 MST", FamilyRFC1123},
+		{"Mon Jan  2 15:04:05 2006", FamilyRFC1123},
+		{"01/02/2006", FamilySlash},
+		{"2006/01/02 15:04", FamilySlash},
+		{"May 8, 2009", FamilyAlpha},
+		{"2 Jan 2006", FamilyAlpha},
+		{"March 3rd, 2020", FamilyAlpha},
+		{"02/Jan/2006:15:04:05 -0700", FamilyAlpha},
+		{"2006年01月02日", FamilyCJK},
+		{"下午3点", FamilyCJK},
+		{"3 days ago", FamilyRelative},
+		{"1332151919", FamilyEpoch},
+		{"1332151919.123", FamilyEpoch},
+		{"now", FamilyKeyword},
+		{"noon", FamilyKeyword},
+	}
+	for _, tc := range tests {
+		p, err := NewParser(AllowFamilies(tc.family))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.ParseAny(tc.in); err != nil {
+			t.Errorf("%q allowing %s: %v", tc.in, tc.family, err)
+		}
+		p, err = NewParser(DenyFamilies(tc.family))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res, err := p.ParseAny(tc.in); !errors.Is(err, ErrFamilyNotAllowed) {
+			t.Errorf("%q denying %s: got %v %v", tc.in, tc.family, res.Time, err)
+		}
+	}
+
+	// zones written out are not ISO 8601, whatever the date
+	p, err := NewParser(AllowFamilies(FamilyISO | FamilyEpoch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range []string{"2020-02-02 15:04:05 America/New_York", "Asia/Shanghai 2020-02-02",
+		"2020-02-02 15:04:05 GMT+8", "2020-02-02 15:04:05 UTC-03:30", "2020-02-02 15:04:05 A"} {
+		if res, err := p.ParseAny(in); !errors.Is(err, ErrFamilyNotAllowed) {
+			t.Errorf("%q allowing ISO|Epoch: got %v %v", in, res.Time, err)
+		}
+	}
+	for _, in := range []string{"2020-02-02T15:04:05+01:00[Europe/Paris]", "2020-02-02T15:04:05+08", "2020-02-02 15:04:05 +0800"} {
+		if _, err := p.ParseAny(in); err != nil {
+			t.Errorf("%q allowing ISO|Epoch: %v", in, err)
+		}
+	}
+
+	if got := (FamilyISO | FamilyEpoch).String(); got != "ISO|Epoch" {
+		t.Errorf("String: got %s", got)
+	}
+}
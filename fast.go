@@ -0,0 +1,174 @@
+package dateparse
+
+import (
+	"time"
+)
+
+// digitLayout describes one of the ISO 8601 layouts timeParse reads
+// without time.Parse.
+type digitLayout struct {
+	// slash is set for 2006/01/02 rather than 2006-01-02
+	slash bool
+	// sep is the byte between date and time, 0 for a date only
+	sep byte
+	// seconds is set when the time has seconds, which may carry a fraction
+	seconds bool
+	// z is set for a literal Z after the time
+	z bool
+	// offset is " -0700", "-07:00", ..., empty without an offset
+	offset string
+}
+
+var digitLayouts = map[string]digitLayout{
+	"2006-01-02":                 {},
+	"2006-01-02 15:04:05":        {sep: ' ', seconds: true},
+	"2006-01-02T15:04:05":        {sep: 'T', seconds: true},
+	"2006-01-02T15:04:05Z":       {sep: 'T', seconds: true, z: true},
+	"2006-01-02T15:04Z":          {sep: 'T', z: true},
+	"2006-01-02T15:04:05-0700":   {sep: 'T', seconds: true, offset: "-0700"},
+	"2006-01-02T15:04:05-07:00":  {sep: 'T', seconds: true, offset: "-07:00"},
+	"2006-01-02 15:04:05 -0700":  {sep: ' ', seconds: true, offset: " -0700"},
+	"2006-01-02 15:04:05 -07:00": {sep: ' ', seconds: true, offset: " -07:00"},
+	"2006-01-02 15:04:05-07:00":  {sep: ' ', seconds: true, offset: "-07:00"},
+	"2006/01/02 15:04":           {slash: true, sep: ' '},
+	"2006/01/02 15:04:05":        {slash: true, sep: ' ', seconds: true},
+}
+
+// timeParse is time.Parse, or time.ParseInLocation when loc is not nil.  The
+// layouts of digitLayouts are read by hand, which is several times faster,
+// anything else goes to time.Parse, which also gives the errors.
+func timeParse(layout, value string, loc *time.Location) (time.Time, error) {
+	if dl, ok := digitLayouts[layout]; ok {
+		if t, ok := dl.parse(value, loc); ok {
+			return t, nil
+		}
+	}
+	if loc == nil {
+		return time.Parse(layout, value)
+	}
+	return time.ParseInLocation(layout, value, loc)
+}
+
+// parse reads value as time.Parse would with the layout dl describes, ok is
+// false for values time.Parse may treat differently.
+func (dl digitLayout) parse(value string, loc *time.Location) (time.Time, bool) {
+	// 2006-01-02
+	dash := byte('-')
+	if dl.slash {
+		dash = '/'
+	}
+	if len(value) < 10 || value[4] != dash || value[7] != dash {
+		return time.Time{}, false
+	}
+	year, ok1 := digits(value[0:4])
+	month, ok2 := digits(value[5:7])
+	day, ok3 := digits(value[8:10])
+	if !ok1 || !ok2 || !ok3 || month < 1 || month > 12 || day < 1 || (day > 28 && day > daysIn(year, time.Month(month))) {
+		return time.Time{}, false
+	}
+	s := value[10:]
+	var hour, min, sec, nsec int
+	if dl.sep != 0 {
+		// T15:04
+		if len(s) < 6 || s[0] != dl.sep || s[3] != ':' {
+			return time.Time{}, false
+		}
+		var ok1, ok2 bool
+		hour, ok1 = digits(s[1:3])
+		min, ok2 = digits(s[4:6])
+		if !ok1 || !ok2 || hour > 23 || min > 59 {
+			return time.Time{}, false
+		}
+		s = s[6:]
+	}
+	if dl.seconds {
+		// :05.999999999
+		if len(s) < 3 || s[0] != ':' {
+			return time.Time{}, false
+		}
+		var ok bool
+		if sec, ok = digits(s[1:3]); !ok || sec > 59 {
+			return time.Time{}, false
+		}
+		s = s[3:]
+		if len(s) > 1 && s[0] == '.' && isDigit(s[1]) {
+			n := 1
+			for n < len(s) && isDigit(s[n]) {
+				n++
+			}
+			if n > 10 {
+				return time.Time{}, false
+			}
+			nsec, _ = digits(s[1:n])
+			for i := n; i < 10; i++ {
+				nsec *= 10
+			}
+			s = s[n:]
+		}
+	}
+	if dl.z {
+		if s != "Z" {
+			return time.Time{}, false
+		}
+		s = ""
+	}
+	if dl.offset == "" {
+		if s != "" {
+			return time.Time{}, false
+		}
+		if loc == nil {
+			loc = time.UTC
+		}
+		return time.Date(year, time.Month(month), day, hour, min, sec, nsec, loc), true
+	}
+
+	// -07:00
+	if s != "" && dl.offset[0] == ' ' {
+		if s[0] != ' ' {
+			return time.Time{}, false
+		}
+		s = s[1:]
+	}
+	colon := dl.offset[len(dl.offset)-3] == ':'
+	if len(s) != len("-0700")+btoi(colon) || (s[0] != '+' && s[0] != '-') || (colon && s[3] != ':') {
+		return time.Time{}, false
+	}
+	oh, ok1 := digits(s[1:3])
+	om, ok2 := digits(s[len(s)-2:])
+	if !ok1 || !ok2 || oh > 23 || om > 59 {
+		return time.Time{}, false
+	}
+	offset := (oh*60 + om) * 60
+	if s[0] == '-' {
+		offset = -offset
+	}
+	// time.Parse uses the local zone, or loc, when it has the offset at
+	// that time and a zone of just the offset otherwise
+	if loc == nil {
+		loc = time.Local
+	}
+	t := time.Date(year, time.Month(month), day, hour, min, sec, nsec, time.UTC).Add(-time.Duration(offset) * time.Second)
+	if _, off := t.In(loc).Zone(); off == offset {
+		return t.In(loc), true
+	}
+	return t.In(time.FixedZone("", offset)), true
+}
+
+// digits reads s, which must be all ASCII digits, as a number.
+func digits(s string) (int, bool) {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return 0, false
+		}
+		n = n*10 + int(s[i]-'0')
+	}
+	return n, true
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,70 @@
+package dateparse
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestTimeParseDigits(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	values := []string{
+		"2014-04-26",
+		"2014-02-29",
+		"2016-02-29",
+		"2014-13-01",
+		"2014-04-26 17:24:37",
+		"2014-04-26 17:24:37.3186369",
+		"2014-04-26 17:24:37.1234567891",
+		"2014-04-26 17:24:37,123",
+		"2014-04-26 24:00:00",
+		"2014-04-26 17:24:60",
+		"2014-04-26 7:24:37",
+		"2014-04-26T17:24:37",
+		"2014-04-26T17:24:37Z",
+		"2014-04-26T17:24:37.123Z",
+		"2014-04-26T17:24Z",
+		"2009-08-12T22:15:09-07:00",
+		"2009-08-12T22:15:09.99-04:00",
+		"2009-08-12T22:15:09+0000",
+		"2009-08-12T22:15:09-0500",
+		"2009-08-12T22:15:09+25:00",
+		"2009-01-12 22:15:09 -0500",
+		"2009-08-12 22:15:09 -07:00",
+		"2009-08-12 22:15:09+00:00",
+		"2009-08-12 22:15:09 +05:30",
+		"2014/04/26 17:24",
+		"2014/04/26 17:24:37",
+		"2014/04/26 17:24:37.3186369",
+		"2014/02/29 17:24:37",
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i, n := 0, len(values); i < n; i++ {
+		for j := 0; j < 20; j++ {
+			b := []byte(values[i])
+			b[rnd.Intn(len(b))] = "0123456789-+:. TZ/"[rnd.Intn(18)]
+			values = append(values, string(b))
+		}
+	}
+	for layout := range digitLayouts {
+		for _, value := range values {
+			for _, loc := range []*time.Location{nil, time.UTC, ny} {
+				var want time.Time
+				var wantErr error
+				if loc == nil {
+					want, wantErr = time.Parse(layout, value)
+				} else {
+					want, wantErr = time.ParseInLocation(layout, value, loc)
+				}
+				got, err := timeParse(layout, value, loc)
+				if (err == nil) != (wantErr == nil) {
+					t.Errorf("%s %q %v: got err %v want %v", layout, value, loc, err, wantErr)
+					continue
+				}
+				if err == nil && (!got.Equal(want) || got.String() != want.String() || got.Location().String() != want.Location().String()) {
+					t.Errorf("%s %q %v: got %v want %v", layout, value, loc, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package dateparse
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Match is a date found in free text by FindAll.
+type Match struct {
+	// Start and End are the byte offsets of the date, text[Start:End].
+	Start, End int
+	ParseResult
+}
+
+// FindAll scans free text such as an email, ticket or article and returns
+// every date or time in it, in order of appearance.
+//
+//     dateparse.FindAll("Shipped on Mon, 02 Jan 2006 15:04:05 MST, arrives 2006年01月05日")
+//
+// A date starts at a digit, a chinese day period such as 下午 or a month or
+// weekday name on a word boundary, and the longest run of text from there
+// that the state machine parses as a whole is taken.  Bare numbers are not reported: "3" or "2014" in prose is
+// rarely an epoch or a year.
+func FindAll(text string) []Match {
+	return defaultParser.FindAll(text)
+}
+
+// FindAll returns every date in text, see the package level FindAll.
+func (p *Parser) FindAll(text string) []Match {
+	var matches []Match
+	for i := 0; i < len(text); {
+		if isMatchStart(text, i) {
+			if m, ok := p.matchAt(text, i); ok {
+				matches = append(matches, m)
+				i = m.End
+				continue
+			}
+			if isAlnum(text[i]) {
+				// skip the rest of the word, dates don't start mid-word
+				for i < len(text) && isAlnum(text[i]) {
+					i++
+				}
+				continue
+			}
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	return matches
+}
+
+func (p *Parser) matchAt(text string, start int) (Match, bool) {
+	var buf [maxPrefixLen + 1]int
+	ends := matchEnds(text, start, buf[:0])
+	for i := len(ends) - 1; i >= 0; i-- {
+		datestr := text[start:ends[i]]
+		if isDigits(datestr) {
+			continue
+		}
+		ps := parser{Parser: p, strict: true, prefix: true}
+		res, err := ps.run(datestr)
+		if err != nil || res.State == StateTimestamp {
+			continue
+		}
+		return Match{Start: start, End: ends[i], ParseResult: res}, true
+	}
+	return Match{}, false
+}
+
+// matchEnds appends to ends each offset within maxPrefixLen bytes of start
+// where a date could end: a rune boundary not inside a word or number, and
+// not just after whitespace.
+func matchEnds(text string, start int, ends []int) []int {
+	for e := start + 1; e <= len(text) && e <= start+maxPrefixLen; e++ {
+		if e < len(text) && (isAlnum(text[e]) || !utf8.RuneStart(text[e])) {
+			continue
+		}
+		switch text[e-1] {
+		case ' ', '\t', '\n', '\r':
+			continue
+		}
+		ends = append(ends, e)
+	}
+	return ends
+}
+
+// isMatchStart reports whether a date may start at text[i]: a digit, a
+// chinese day period such as 下午 or a month or weekday name that doesn't
+// continue a word or number.  Dates directly following CJK text
+// ("会议定于2020年") are allowed.
+func isMatchStart(text string, i int) bool {
+	if i > 0 && isAlnum(text[i-1]) {
+		return false
+	}
+	c := text[i]
+	switch {
+	case c >= '0' && c <= '9':
+		return true
+	case c >= utf8.RuneSelf:
+		for _, dp := range chineseDayPeriods {
+			if strings.HasPrefix(text[i:], dp.word) {
+				return true
+			}
+		}
+	case isAlpha(c):
+		j := i
+		for j < len(text) && isAlpha(text[j]) {
+			j++
+		}
+		return isMonthOrDayName(text[i:j])
+	}
+	return false
+}
+
+var monthAndDayNames = []string{
+	"january", "february", "march", "april", "may", "june", "july",
+	"august", "september", "october", "november", "december",
+	"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday",
+}
+
+// isMonthOrDayName reports whether word is a month or weekday name or an
+// abbreviation of one at least three letters long, ignoring case.
+func isMonthOrDayName(word string) bool {
+	if len(word) < 3 {
+		return false
+	}
+	word = strings.ToLower(word)
+	for _, name := range monthAndDayNames {
+		if strings.HasPrefix(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isAlnum(c byte) bool {
+	return isAlpha(c) || (c >= '0' && c <= '9')
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
@@ -0,0 +1,91 @@
+package dateparse
+
+import (
+	"testing"
+)
+
+func TestFindAll(t *testing.T) {
+	text := "Shipped on Mon, 02 Jan 2006 15:04:05 MST, arrives 2006-01-05. " +
+		"Order 20140601 of 3 items. 会议定于2017年11月01日 09:41举行。"
+	want := []string{
+		"Mon, 02 Jan 2006 15:04:05 MST",
+		"2006-01-05",
+		"2017年11月01日 09:41",
+	}
+	matches := FindAll(text)
+	if len(matches) != len(want) {
+		t.Fatalf("got %d matches %+v want %d", len(matches), matches, len(want))
+	}
+	for i, m := range matches {
+		if got := text[m.Start:m.End]; got != want[i] {
+			t.Errorf("match %d: got %q want %q", i, got, want[i])
+		}
+		if m.Time.IsZero() || m.Layout == "" {
+			t.Errorf("match %d: missing time or layout %+v", i, m)
+		}
+	}
+}
+
+func TestFindAllCases(t *testing.T) {
+	type span struct {
+		start, end int
+		out        string
+	}
+	tests := []struct {
+		text string
+		want []span
+	}{
+		// prose without dates
+		{"You may sun yourself in March if you like", nil},
+		{"may sun mon", nil},
+		{"Upgrade from 1.2.3 to v2.10.1 before release 3.4", nil},
+		{"Call 555-123-4567 or +1 (800) 555-0199", nil},
+		{"tel 13800138000, version 2.1", nil},
+		// Chinese text
+		{"会议定于2017年11月01日举行，截止2017-11-05。", []span{
+			{12, 29, "2017-11-01 00:00:00 +0000 UTC"},
+			{44, 54, "2017-11-05 00:00:00 +0000 UTC"},
+		}},
+		{"会议定于2020年3月5日下午3点召开", []span{
+			{12, 37, "2020-03-05 15:00:00 +0000 UTC"},
+		}},
+		{"下午好，截止2020年3月5日", []span{
+			{18, 33, "2020-03-05 00:00:00 +0000 UTC"},
+		}},
+		// adjacent dates
+		{"2006-01-02 2006-01-03", []span{
+			{0, 10, "2006-01-02 00:00:00 +0000 UTC"},
+			{11, 21, "2006-01-03 00:00:00 +0000 UTC"},
+		}},
+		{"2006-01-02,2006-01-03", []span{
+			{0, 10, "2006-01-02 00:00:00 +0000 UTC"},
+			{11, 21, "2006-01-03 00:00:00 +0000 UTC"},
+		}},
+		{"10/11/2012 12/1/2013", []span{
+			{0, 10, "2012-10-11 00:00:00 +0000 UTC"},
+			{11, 20, "2013-12-01 00:00:00 +0000 UTC"},
+		}},
+		// byte offsets around multi-byte runes
+		{"é 2014-04-26 ü", []span{
+			{3, 13, "2014-04-26 00:00:00 +0000 UTC"},
+		}},
+		{"日期：2006-01-02 “Jan 5, 2014”", []span{
+			{9, 19, "2006-01-02 00:00:00 +0000 UTC"},
+			{23, 34, "2014-01-05 00:00:00 +0000 UTC"},
+		}},
+	}
+	for _, tc := range tests {
+		matches := FindAll(tc.text)
+		if len(matches) != len(tc.want) {
+			t.Errorf("%q: got %d matches %+v want %d", tc.text, len(matches), matches, len(tc.want))
+			continue
+		}
+		for i, m := range matches {
+			w := tc.want[i]
+			if m.Start != w.start || m.End != w.end || m.Time.String() != w.out {
+				t.Errorf("%q match %d: got [%d:%d] %s want [%d:%d] %s",
+					tc.text, i, m.Start, m.End, m.Time, w.start, w.end, w.out)
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package dateparse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatReport is the outcome of InferFormat.
+type FormatReport struct {
+	// Layout is the time.Parse layout that fits the most samples, empty
+	// when the samples are not parsed with a layout (unix timestamps,
+	// "3 days ago").
+	Layout string
+	// State is the format type of Layout.
+	State DateState
+	// DayFirst is set for slash dates read day first, 02/01/2006.
+	DayFirst bool
+	// Ambiguous is set when no sample told day first and month first
+	// apart, every day was 12 or less.  Layout is month first then.
+	Ambiguous bool
+	// Matched is the number of samples Layout parses.
+	Matched int
+	// Total is the number of samples that are not blank.
+	Total int
+	// Confidence is Matched / Total.
+	Confidence float64
+	// Outliers are the indexes of the samples Layout doesn't parse.
+	Outliers []int
+}
+
+// InferFormat picks the one layout that parses the most of samples, the
+// values of one column, so they can all be read the same way.  Slash dates
+// are tried both month and day first, a day above 12 decides between them.
+// Blank samples are skipped.
+//
+//     report, err := dateparse.InferFormat([]string{"03/02/2020", "13/02/2020", "n/a"})
+//     // report.Layout == "02/01/2006", report.DayFirst, report.Outliers == []int{2}
+//
+func InferFormat(samples []string) (FormatReport, error) {
+	return defaultParser.InferFormat(samples)
+}
+
+// inferCandidate is a layout InferFormat considers.
+type inferCandidate struct {
+	layout string
+	state  DateState
+	// detected counts the samples ParseAny read with the layout, it breaks
+	// ties between layouts that parse the same samples
+	detected int
+	matched  int
+}
+
+// InferFormat is the package level InferFormat with the options of p.
+func (p *Parser) InferFormat(samples []string) (FormatReport, error) {
+	var cands []*inferCandidate
+	add := func(layout string, state DateState) *inferCandidate {
+		for _, c := range cands {
+			if c.layout == layout && c.state == state {
+				return c
+			}
+		}
+		c := &inferCandidate{layout: layout, state: state}
+		cands = append(cands, c)
+		return c
+	}
+
+	results := make([]*ParseResult, len(samples))
+	total, slashes := 0, false
+	for i, s := range samples {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		total++
+		if res, err := p.parse(s, nil); err == nil {
+			results[i] = &res
+			add(res.Layout, res.State).detected++
+		}
+		if strings.IndexByte(s, '/') >= 0 {
+			slashes = true
+		}
+	}
+	if total == 0 {
+		return FormatReport{}, fmt.Errorf("no samples to infer a date format from")
+	}
+	if slashes {
+		// day first dates beyond the 12th don't parse month first at all
+		for _, layout := range shortDates {
+			add(layout, StateDigitSlash)
+		}
+		for _, state := range []DateState{StateDigitSlashWSColon, StateDigitSlashWSColonAMPM,
+			StateDigitSlashWSColonColon, StateDigitSlashWSColonColonAMPM} {
+			for _, layout := range slashLayouts[state].monthFirst {
+				add(layout, state)
+			}
+		}
+		for _, c := range cands {
+			if df, ok := swapMonthDay(c.layout); ok {
+				add(df, c.state)
+			}
+		}
+	}
+
+	var best *inferCandidate
+	for _, c := range cands {
+		for i, s := range samples {
+			if c.matches(p, strings.TrimSpace(s), results[i]) {
+				c.matched++
+			}
+		}
+		if best == nil || c.matched > best.matched || (c.matched == best.matched && c.detected > best.detected) {
+			best = c
+		}
+	}
+	if best == nil || best.matched == 0 {
+		return FormatReport{}, fmt.Errorf("Could not find date format for any of %d samples", total)
+	}
+
+	report := FormatReport{
+		Layout:     best.layout,
+		State:      best.state,
+		DayFirst:   isDayFirstLayout(best.layout),
+		Matched:    best.matched,
+		Total:      total,
+		Confidence: float64(best.matched) / float64(total),
+	}
+	if other, ok := swapMonthDay(best.layout); ok {
+		for _, c := range cands {
+			if c.layout == other && c.state == best.state && c.matched == best.matched {
+				report.Ambiguous = true
+			}
+		}
+	}
+	for i, s := range samples {
+		if s = strings.TrimSpace(s); s != "" && !best.matches(p, s, results[i]) {
+			report.Outliers = append(report.Outliers, i)
+		}
+	}
+	return report, nil
+}
+
+// matches reports whether the candidate parses s, res is ParseAny's result
+// for s if it had one.
+func (c *inferCandidate) matches(p *Parser, s string, res *ParseResult) bool {
+	if s == "" {
+		return false
+	}
+	if c.layout == "" {
+		return res != nil && res.Layout == "" && res.State == c.state
+	}
+	ps := parser{Parser: p}
+	_, err := ps.parse(c.layout, s)
+	return err == nil
+}
+
+// monthDayPrefixes are the starts of month first slash layouts and their
+// day first versions.
+var monthDayPrefixes = [][2]string{
+	{"01/02/", "02/01/"},
+	{"01/2/", "02/1/"},
+	{"1/02/", "2/01/"},
+	{"1/2/", "2/1/"},
+}
+
+// swapMonthDay swaps the month and day of a slash layout, "01/02/2006"
+// becomes "02/01/2006" and back.
+func swapMonthDay(layout string) (string, bool) {
+	for _, md := range monthDayPrefixes {
+		if strings.HasPrefix(layout, md[0]) {
+			return md[1] + layout[len(md[0]):], true
+		}
+		if strings.HasPrefix(layout, md[1]) {
+			return md[0] + layout[len(md[1]):], true
+		}
+	}
+	return layout, false
+}
+
+func isDayFirstLayout(layout string) bool {
+	for _, md := range monthDayPrefixes {
+		if strings.HasPrefix(layout, md[1]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package dateparse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInferFormat(t *testing.T) {
+	tests := []struct {
+		samples   []string
+		layout    string
+		dayFirst  bool
+		ambiguous bool
+		outliers  []int
+	}{
+		{[]string{"03/02/2020", "13/02/2020", "n/a", "", "28/12/2019"}, "02/01/2006", true, false, []int{2}},
+		{[]string{"03/02/2020", "12/13/2020", "1/2/2020"}, "1/2/2006", false, false, nil},
+		{[]string{"03/02/2020", "04/05/2020"}, "01/02/2006", false, true, nil},
+		{[]string{"25/12/2019 10:11:59", "01/02/2020 10:11:59", "2/3/2020 08:00:00"}, "2/1/2006 15:04:05", true, false, nil},
+		{[]string{"2020-02-02", "2020-02-03", "02/03/2020"}, "2006-01-02", false, false, []int{2}},
+		{[]string{"1332151919", "1332151920", "2020-02-02"}, "", false, false, []int{2}},
+	}
+	for _, tc := range tests {
+		r, err := InferFormat(tc.samples)
+		if err != nil {
+			t.Errorf("%q: %v", tc.samples, err)
+			continue
+		}
+		if r.Layout != tc.layout || r.DayFirst != tc.dayFirst || r.Ambiguous != tc.ambiguous || !reflect.DeepEqual(r.Outliers, tc.outliers) {
+			t.Errorf("%q: got %+v", tc.samples, r)
+		}
+		if r.Total != r.Matched+len(r.Outliers) {
+			t.Errorf("%q: counts %+v", tc.samples, r)
+		}
+	}
+
+	if _, err := InferFormat([]string{"", "foo"}); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,234 @@
+package dateparse
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// layoutElem is an element of a time.Parse layout, a coarser version of the
+// std chunks in the time package.
+type layoutElem int
+
+const (
+	elemNone     layoutElem = iota
+	elemYear                // 2006
+	elemYear2               // 06
+	elemMonth               // January Jan 01 1
+	elemDay                 // 02 _2 2 002
+	elemWeekday             // Monday Mon
+	elemHour                // 15 03 3
+	elemMinute              // 04 4
+	elemSecond              // 05 5
+	elemFraction            // .000 .999 ,000
+	elemAMPM                // PM pm
+	elemZone                // MST
+	elemOffset              // -0700 -07:00 -07 Z07:00 Z0700 ...
+)
+
+var layoutOffsets = []string{"-07:00:00", "-070000", "-07:00", "-0700", "-07"}
+
+// nextLayoutElem splits layout around its first element the same way
+// time.Parse does (see nextStdChunk in the time package).  elem is elemNone
+// when the layout has no more elements.
+func nextLayoutElem(layout string) (prefix string, elem layoutElem, suffix string) {
+	for i := 0; i < len(layout); i++ {
+		switch c := layout[i]; c {
+		case 'J': // January, Jan
+			if strings.HasPrefix(layout[i:], "January") {
+				return layout[:i], elemMonth, layout[i+7:]
+			}
+			if strings.HasPrefix(layout[i:], "Jan") {
+				return layout[:i], elemMonth, layout[i+3:]
+			}
+		case 'M': // Monday, Mon, MST
+			if strings.HasPrefix(layout[i:], "Monday") {
+				return layout[:i], elemWeekday, layout[i+6:]
+			}
+			if strings.HasPrefix(layout[i:], "Mon") {
+				return layout[:i], elemWeekday, layout[i+3:]
+			}
+			if strings.HasPrefix(layout[i:], "MST") {
+				return layout[:i], elemZone, layout[i+3:]
+			}
+		case '0': // 01, 02, 03, 04, 05, 06, 002
+			if i+1 < len(layout) && '1' <= layout[i+1] && layout[i+1] <= '6' {
+				elems := [...]layoutElem{elemMonth, elemDay, elemHour, elemMinute, elemSecond, elemYear2}
+				return layout[:i], elems[layout[i+1]-'1'], layout[i+2:]
+			}
+			if strings.HasPrefix(layout[i:], "002") {
+				return layout[:i], elemDay, layout[i+3:]
+			}
+		case '1': // 15, 1
+			if strings.HasPrefix(layout[i:], "15") {
+				return layout[:i], elemHour, layout[i+2:]
+			}
+			return layout[:i], elemMonth, layout[i+1:]
+		case '2': // 2006, 2
+			if strings.HasPrefix(layout[i:], "2006") {
+				return layout[:i], elemYear, layout[i+4:]
+			}
+			return layout[:i], elemDay, layout[i+1:]
+		case '_': // _2, _2006, __2
+			if strings.HasPrefix(layout[i:], "_2") {
+				// _2006 is really a literal _, followed by stdLongYear
+				if strings.HasPrefix(layout[i+1:], "2006") {
+					return layout[:i+1], elemYear, layout[i+5:]
+				}
+				return layout[:i], elemDay, layout[i+2:]
+			}
+			if strings.HasPrefix(layout[i:], "__2") {
+				return layout[:i], elemDay, layout[i+3:]
+			}
+		case '3':
+			return layout[:i], elemHour, layout[i+1:]
+		case '4':
+			return layout[:i], elemMinute, layout[i+1:]
+		case '5':
+			return layout[:i], elemSecond, layout[i+1:]
+		case 'P': // PM
+			if strings.HasPrefix(layout[i:], "PM") {
+				return layout[:i], elemAMPM, layout[i+2:]
+			}
+		case 'p': // pm
+			if strings.HasPrefix(layout[i:], "pm") {
+				return layout[:i], elemAMPM, layout[i+2:]
+			}
+		case '-', 'Z': // -070000, -07:00:00, -0700, -07:00, -07 and the Z variants
+			for _, off := range layoutOffsets {
+				if strings.HasPrefix(layout[i+1:], off[1:]) {
+					return layout[:i], elemOffset, layout[i+len(off):]
+				}
+			}
+		case '.', ',': // ,000, or .000, or ,999, or .999 - repeated digits for fractional seconds.
+			if i+1 < len(layout) && (layout[i+1] == '0' || layout[i+1] == '9') {
+				ch := layout[i+1]
+				j := i + 1
+				for j < len(layout) && layout[j] == ch {
+					j++
+				}
+				// String of digits must end here - only fractional second if all digits match.
+				if j >= len(layout) || layout[j] < '0' || layout[j] > '9' {
+					return layout[:i], elemFraction, layout[j:]
+				}
+			}
+		}
+	}
+	return layout, elemNone, ""
+}
+
+// layoutHas reports whether layout contains any of the elements.
+func layoutHas(layout string, elems ...layoutElem) bool {
+	for {
+		_, elem, suffix := nextLayoutElem(layout)
+		if elem == elemNone {
+			return false
+		}
+		for _, e := range elems {
+			if elem == e {
+				return true
+			}
+		}
+		layout = suffix
+	}
+}
+
+// layoutInfo is what a parse asks about the elements of a layout.
+type layoutInfo struct {
+	layout              string
+	zone, offset, year2 bool
+	// precision is the finest precision of the elements
+	precision Precision
+}
+
+// layoutInfos remembers the layoutInfo of the layouts used last, walking a
+// layout costs more than the rest of a parse's bookkeeping.  A layout goes
+// to one of two slots picked by its hash, the empty one or else the second,
+// which keeps the lookup free of locks and of the interface hashing of a
+// sync.Map.
+var layoutInfos [256]atomic.Value // of layoutInfo
+
+// infoOf returns the layoutInfo of layout.
+func infoOf(layout string) layoutInfo {
+	h := uint32(2166136261) // FNV-1a
+	for i := 0; i < len(layout); i++ {
+		h = (h ^ uint32(layout[i])) * 16777619
+	}
+	n := uint32(len(layoutInfos))
+	first, second := &layoutInfos[h%n], &layoutInfos[(h>>16)%n]
+	info, used := first.Load().(layoutInfo)
+	if used && info.layout == layout {
+		return info
+	}
+	if info, ok := second.Load().(layoutInfo); ok && info.layout == layout {
+		return info
+	}
+	slot := first
+	if used {
+		slot = second
+	}
+	info = layoutInfo{layout: layout}
+	for s := layout; ; {
+		_, elem, suffix := nextLayoutElem(s)
+		switch elem {
+		case elemNone:
+			slot.Store(info)
+			return info
+		case elemYear2:
+			info.year2 = true
+		case elemZone:
+			info.zone = true
+		case elemOffset:
+			info.offset = true
+		}
+		if ep := elemPrecision[elem]; ep > info.precision {
+			info.precision = ep
+		}
+		s = suffix
+	}
+}
+
+// layoutZoned reports whether layout has a zone or an offset.
+func layoutZoned(layout string) bool {
+	// zones are MST and offsets -07 or Z07, a layout without 07 or MST has
+	// neither
+	if !strings.Contains(layout, "07") && !strings.Contains(layout, "MST") {
+		return false
+	}
+	info := infoOf(layout)
+	return info.zone || info.offset
+}
+
+// layoutZoneOnly reports whether layout has a zone name but no offset.
+func layoutZoneOnly(layout string) bool {
+	if !strings.Contains(layout, "MST") {
+		return false
+	}
+	info := infoOf(layout)
+	return info.zone && !info.offset
+}
+
+// layoutYear2 reports whether layout has a two digit year.
+func layoutYear2(layout string) bool {
+	// every 06 of the layout belongs to a 2006 otherwise
+	if strings.Count(layout, "06") == strings.Count(layout, "2006") {
+		return false
+	}
+	return infoOf(layout).year2
+}
+
+// layoutPrecision returns the finest precision of the elements in layout.
+func layoutPrecision(layout string) Precision {
+	return infoOf(layout).precision
+}
+
+var elemPrecision = [...]Precision{
+	elemYear:     PrecisionYear,
+	elemYear2:    PrecisionYear,
+	elemMonth:    PrecisionMonth,
+	elemDay:      PrecisionDay,
+	elemHour:     PrecisionHour,
+	elemMinute:   PrecisionMinute,
+	elemSecond:   PrecisionSecond,
+	elemFraction: PrecisionSubsecond,
+	elemOffset:   PrecisionUnknown,
+}
@@ -0,0 +1,257 @@
+package dateparse
+
+import (
+	"strings"
+	"time"
+)
+
+// longFormNoise are words allowed, and ignored, in long form dates.
+var longFormNoise = map[string]bool{
+	"of":  true,
+	"at":  true,
+	"the": true,
+	"on":  true,
+}
+
+// parseLongForm parses long form english dates, the ones people write
+// rather than programs, which vary too much for a fixed layout:
+//
+//     March 3rd, 2020
+//     3rd of March 2020
+//     Tuesday, March 3rd 2020 at 4:05pm
+//     Sept. 3, 2020
+//     Mar 3 2020 16:05
+//     March 3 at 4 p.m.
+//
+// The input is read as tokens: month and weekday names (or abbreviations of
+// at least three letters), day numbers with an optional ordinal suffix, a
+// four digit year, a h:mm[:ss[.fff]] time and am/pm.  Commas, periods and the
+// words "of", "at", "the" and "on" are ignored.  A missing year is inferred
+// from the reference clock.
+func (p *parser) parseLongForm(datestr string) (time.Time, bool) {
+	var (
+		month                time.Month
+		year, day            = -1, -1
+		hour, min, sec, nsec = -1, 0, 0, 0
+		ampm                 string
+		noise                bool
+		prec                 = PrecisionDay
+		loc                  = p.loc
+		s                    = datestr
+	)
+	for len(s) > 0 {
+		c := s[0]
+		switch {
+		case c == ' ' || c == '\t' || c == ',' || c == '.':
+			s = s[1:]
+			continue
+
+		case isAlpha(c):
+			j := 0
+			for j < len(s) && isAlpha(s[j]) {
+				j++
+			}
+			word := strings.ToLower(s[:j])
+			upper := s[:j] == strings.ToUpper(s[:j])
+			s = s[j:]
+			if zone, ok, err := p.zoneAbbrev(strings.ToUpper(word)); ok && (upper || word == "utc" || word == "gmt") {
+				// March 3 at 4pm EST
+				if err != nil {
+					return time.Time{}, false
+				}
+				loc = zone
+				noise = false
+				continue
+			}
+			switch {
+			case word == "am" || word == "pm":
+				ampm = word
+			case (word == "a" || word == "p") && strings.HasPrefix(s, ".m"):
+				// a.m. p.m.
+				ampm = word + "m"
+				s = s[2:]
+			case longFormNoise[word]:
+				noise = true
+				continue
+			case isWeekdayName(word):
+			default:
+				m := lookupMonth(word)
+				if m == 0 || month != 0 {
+					return time.Time{}, false
+				}
+				month = m
+			}
+
+		case c >= '0' && c <= '9':
+			j := 0
+			n := 0
+			for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+				n = n*10 + int(s[j]-'0')
+				j++
+			}
+			digits := j
+			s = s[j:]
+			switch {
+			case strings.HasPrefix(s, ":"):
+				// 4:05 16:05:30 16:05:30.123
+				if hour >= 0 || digits > 2 {
+					return time.Time{}, false
+				}
+				hour = n
+				var ok bool
+				if min, s, ok = twoDigits(s[1:]); !ok {
+					return time.Time{}, false
+				}
+				prec = PrecisionMinute
+				if strings.HasPrefix(s, ":") {
+					if sec, s, ok = twoDigits(s[1:]); !ok {
+						return time.Time{}, false
+					}
+					prec = PrecisionSecond
+					if len(s) > 1 && s[0] == '.' && s[1] >= '0' && s[1] <= '9' {
+						nsec, s = fraction(s[1:])
+						prec = PrecisionSubsecond
+					}
+				}
+			case hasOrdinalSuffix(s):
+				// 3rd 21st
+				if day >= 0 || digits > 2 {
+					return time.Time{}, false
+				}
+				day = n
+				s = s[2:]
+			case digits == 4:
+				if year >= 0 {
+					return time.Time{}, false
+				}
+				year = n
+			case digits <= 2 && hasAMPM(strings.TrimLeft(s, " ")):
+				// 4pm 4 p.m.
+				if hour >= 0 {
+					return time.Time{}, false
+				}
+				hour = n
+				prec = PrecisionHour
+			case digits <= 2 && day < 0:
+				day = n
+			default:
+				return time.Time{}, false
+			}
+
+		default:
+			return time.Time{}, false
+		}
+		noise = false
+	}
+
+	// "March 3 at" and "March 3," are a date followed by something else
+	if noise || strings.HasSuffix(datestr, ",") || month == 0 || day < 1 || (hour < 0 && ampm != "") {
+		return time.Time{}, false
+	}
+	if hour < 0 {
+		hour = 0
+	}
+	if ampm != "" {
+		if hour < 1 || hour > 12 {
+			return time.Time{}, false
+		}
+		hour %= 12
+		if ampm == "pm" {
+			hour += 12
+		}
+	}
+	if hour > 23 || min > 59 || sec > 59 {
+		return time.Time{}, false
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	inferYear := year < 0
+	if inferYear {
+		// Feb 29 has to be checked against a leap year
+		year = 0
+	}
+	if day > daysIn(year, month) {
+		return time.Time{}, false
+	}
+
+	var t time.Time
+	var err error
+	if inferYear {
+		t, err = p.inferYear(time.Date(year, month, day, hour, min, sec, nsec, loc))
+	} else {
+		t, err = p.localTime(time.Date(year, month, day, hour, min, sec, nsec, time.UTC), loc)
+	}
+	if err != nil {
+		return time.Time{}, false
+	}
+	p.layout = ""
+	p.precision = prec
+	return t, true
+}
+
+// lookupMonth returns the month word names or abbreviates, ignoring case.
+func lookupMonth(word string) time.Month {
+	if len(word) < 3 {
+		return 0
+	}
+	for i, name := range monthAndDayNames[:12] {
+		if strings.HasPrefix(name, strings.ToLower(word)) {
+			return time.Month(i + 1)
+		}
+	}
+	return 0
+}
+
+// isWeekdayName reports whether word names or abbreviates a day of the week.
+func isWeekdayName(word string) bool {
+	if len(word) < 3 {
+		return false
+	}
+	for _, name := range monthAndDayNames[12:] {
+		if strings.HasPrefix(name, strings.ToLower(word)) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasOrdinalSuffix(s string) bool {
+	if len(s) < 2 || (len(s) > 2 && isAlpha(s[2])) {
+		return false
+	}
+	switch strings.ToLower(s[:2]) {
+	case "st", "nd", "rd", "th":
+		return true
+	}
+	return false
+}
+
+func hasAMPM(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+	switch strings.ToLower(s[:2]) {
+	case "am", "pm", "a.", "p.":
+		return true
+	}
+	return false
+}
+
+// twoDigits reads exactly two digits from the start of s.
+func twoDigits(s string) (int, string, bool) {
+	if len(s) < 2 || s[0] < '0' || s[0] > '9' || s[1] < '0' || s[1] > '9' {
+		return 0, s, false
+	}
+	return int(s[0]-'0')*10 + int(s[1]-'0'), s[2:], true
+}
+
+// fraction reads the digits of a fractional second as nanoseconds.
+func fraction(s string) (int, string) {
+	nsec, scale, i := 0, 100000000, 0
+	for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+		nsec += int(s[i]-'0') * scale
+		scale /= 10
+	}
+	return nsec, s[i:]
+}
@@ -0,0 +1,44 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLongForm(t *testing.T) {
+	now := time.Date(2020, 6, 15, 10, 30, 0, 0, time.UTC)
+	p, err := NewParser(ReferenceClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"March 3rd, 2020", "2020-03-03 00:00:00 +0000 UTC"},
+		{"3rd of March 2020", "2020-03-03 00:00:00 +0000 UTC"},
+		{"the 21st of march, 2019", "2019-03-21 00:00:00 +0000 UTC"},
+		{"Tuesday, March 3rd 2020 at 4:05pm", "2020-03-03 16:05:00 +0000 UTC"},
+		{"Tue March 3rd 2020 at 4:05 PM", "2020-03-03 16:05:00 +0000 UTC"},
+		{"Sept. 3, 2020", "2020-09-03 00:00:00 +0000 UTC"},
+		{"Mar 3 2020 16:05", "2020-03-03 16:05:00 +0000 UTC"},
+		{"March 3 at 4 p.m.", "2020-03-03 16:00:00 +0000 UTC"},
+		{"May 18, 2009 5:57:51 PM", "2009-05-18 17:57:51 +0000 UTC"},
+	}
+	for _, tc := range tests {
+		res, err := p.ParseAny(tc.in)
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+		if got := res.Time.String(); got != tc.out {
+			t.Errorf("%q: got %s want %s", tc.in, got, tc.out)
+		}
+	}
+
+	for _, in := range []string{"March 3rd at", "March 32nd 2020", "Feb 29 2019", "March 3 2020 13:00 pm"} {
+		if res, err := p.ParseAny(in); err == nil {
+			t.Errorf("%q: expected error, got %v", in, res.Time)
+		}
+	}
+}
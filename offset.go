@@ -0,0 +1,234 @@
+package dateparse
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// militaryZones are the offsets, in hours, of the single letter military
+// time zones.  J is local time and has no fixed offset.
+var militaryZones = [26]int{
+	1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 10, 11, 12, // A-M
+	-1, -2, -3, -4, -5, -6, -7, -8, -9, -10, -11, -12, // N-Y
+	0, // Z
+}
+
+// extractOffset finds a UTC offset following the time of day in datestr
+// and returns datestr without it.  These are read:
+//
+//     15:04:05 GMT+8
+//     15:04:05 UTC-03:30
+//     15:04:05 UTC+05
+//     15:04:05 A           military, A-Z except J, only at the end
+//     15:04:05+08
+//     15:04:05 +0800
+//     15:04:05+08:00:00
+//
+// The layouts of parseTime already handle "+0800", "+08:00", "Z" and
+// "GMT+0100" in most places, standard asks for those too, otherwise they
+// are left alone.  A military letter is only read when military is set and
+// the letter ends datestr, elsewhere it is more likely a word of the text
+// following the date, as in "10:30 A started".
+func extractOffset(datestr string, standard, military bool) (string, *time.Location, bool) {
+	// a time of day starts at most two digits before its first colon
+	from := strings.IndexByte(datestr, ':')
+	if from < 0 {
+		return datestr, nil, false
+	}
+	if from -= 2; from < 0 {
+		from = 0
+	}
+	for i := from; i < len(datestr); i++ {
+		end := timeOfDayEnd(datestr, i)
+		if end < 0 {
+			continue
+		}
+		start := end
+		if start < len(datestr) && datestr[start] == ' ' {
+			start++
+		}
+		loc, n, std, ok := readOffset(datestr[start:], start > end, military)
+		if !ok || (std && !standard) {
+			i = end
+			continue
+		}
+		rest := datestr[:end] + datestr[start+n:]
+		if start+n < len(datestr) && end > 0 && start > end {
+			// 15:04:05 UTC+8 2006, keep one space
+			rest = datestr[:end] + " " + strings.TrimLeft(datestr[start+n:], " ")
+		}
+		return rest, loc, true
+	}
+	return datestr, nil, false
+}
+
+// mayHaveOffset reports whether extractOffset could find an offset in
+// datestr: a sign after the first colon, or a military letter at the end.
+// Most date strings have neither and skip the search.
+func mayHaveOffset(datestr string, military bool) bool {
+	i := strings.IndexByte(datestr, ':')
+	if i < 0 {
+		return false
+	}
+	if strings.IndexByte(datestr[i:], '+') >= 0 || strings.IndexByte(datestr[i:], '-') >= 0 {
+		return true
+	}
+	// a letter on its own, not the end of PM or UTC
+	n := len(datestr)
+	c := datestr[n-1]
+	return military && c >= 'A' && c <= 'Z' && c != 'J' && !letterBytes[datestr[n-2]]
+}
+
+// timeOfDayEnd returns the end of a h:mm[:ss[.fff]] [AM] time of day starting
+// at i, or -1.
+func timeOfDayEnd(s string, i int) int {
+	if i > 0 && isDigit(s[i-1]) {
+		return -1
+	}
+	j := i
+	for j < len(s) && j-i < 2 && isDigit(s[j]) {
+		j++
+	}
+	if j == i || j+2 >= len(s) || s[j] != ':' || !isDigit(s[j+1]) || !isDigit(s[j+2]) {
+		return -1
+	}
+	j += 3
+	if j+2 < len(s) && s[j] == ':' && isDigit(s[j+1]) && isDigit(s[j+2]) {
+		j += 3
+		if j+1 < len(s) && (s[j] == '.' || s[j] == ',') && isDigit(s[j+1]) {
+			j++
+			for j < len(s) && isDigit(s[j]) {
+				j++
+			}
+		}
+	}
+	// 3:04 PM 3:04pm
+	k := j
+	if k < len(s) && s[k] == ' ' {
+		k++
+	}
+	if k+2 <= len(s) && (k+2 == len(s) || s[k+2] == ' ') {
+		if w := s[k : k+2]; strings.EqualFold(w, "am") || strings.EqualFold(w, "pm") {
+			j = k + 2
+		}
+	}
+	return j
+}
+
+// readOffset reads an offset at the start of s, n is its length and std
+// reports whether it is a form time.Parse knows.  spaced is set when the
+// offset was separated from the time by a space, military allows a single
+// letter military zone that is all of s.
+func readOffset(s string, spaced, military bool) (loc *time.Location, n int, std, ok bool) {
+	name := ""
+	switch {
+	case strings.HasPrefix(s, "GMT"), strings.HasPrefix(s, "UTC"):
+		name, n = s[:3], 3
+	case strings.HasPrefix(s, "UT"):
+		name, n = s[:2], 2
+	case len(s) > 0 && s[0] >= 'A' && s[0] <= 'Z' && s[0] != 'J':
+		// military
+		if !military || len(s) > 1 {
+			return nil, 0, false, false
+		}
+		if s[0] == 'Z' {
+			return time.UTC, 1, !spaced, true
+		}
+		return time.FixedZone(s[:1], militaryZones[s[0]-'A']*hour), 1, false, true
+	}
+	if n >= len(s) || (s[n] != '+' && s[n] != '-') {
+		// a plain GMT is a zone abbreviation
+		return nil, 0, false, false
+	}
+	sign := 1
+	if s[n] == '-' {
+		sign = -1
+	}
+	n++
+
+	j := n
+	for j < len(s) && isDigit(s[j]) {
+		j++
+	}
+	d := s[n:j]
+	var h, m, sec int
+	colons := 0
+	switch len(d) {
+	case 1:
+		// GMT+8
+		if name == "" {
+			return nil, 0, false, false
+		}
+		h = int(d[0] - '0')
+	case 2:
+		h = atoi2(d)
+		if j+2 < len(s) && s[j] == ':' && isDigit(s[j+1]) && isDigit(s[j+2]) {
+			m, colons = atoi2(s[j+1:]), 1
+			j += 3
+			if j+2 < len(s) && s[j] == ':' && isDigit(s[j+1]) && isDigit(s[j+2]) {
+				sec, colons = atoi2(s[j+1:]), 2
+				j += 3
+			}
+		}
+	case 4:
+		h, m = atoi2(d), atoi2(d[2:])
+	case 6:
+		h, m, sec = atoi2(d), atoi2(d[2:]), atoi2(d[4:])
+	default:
+		return nil, 0, false, false
+	}
+	n = j
+	if n < len(s) && s[n] != ' ' && s[n] != '(' {
+		return nil, 0, false, false
+	}
+	if h > 14 || m > 59 || sec > 59 {
+		return nil, 0, false, false
+	}
+	offset := sign * (h*hour + m*60 + sec)
+	if name == "" {
+		std = len(d) == 4 || (len(d) == 2 && colons == 1)
+		return time.FixedZone("", offset), n, std, true
+	}
+	return time.FixedZone(s[:n], offset), n, len(d) == 4, true
+}
+
+func atoi2(s string) int {
+	return int(s[0]-'0')*10 + int(s[1]-'0')
+}
+
+// parseOffset parses rest, what is left of datestr after extractOffset took
+// loc out, as a wall clock time in loc.  Errors quote datestr.
+func (p *parser) parseOffset(datestr, rest string, loc *time.Location) (time.Time, DateState, error) {
+	if loc != time.UTC && loc.String() != "" {
+		// GMT+8 or a military letter, numeric offsets have no name
+		if err := p.checkZoneFamily(datestr); err != nil {
+			return time.Time{}, StateStart, err
+		}
+	}
+	parseLoc := p.loc
+	p.loc = nil
+	t, state, err := p.parseAny(rest)
+	p.loc = parseLoc
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrFamilyNotAllowed):
+			err = fmt.Errorf("%w: %s is %s", ErrFamilyNotAllowed, datestr, stateFamily(state, rest))
+		case errors.Unwrap(err) == nil:
+			// time.Parse and parseTime quote rest, which the caller
+			// never wrote
+			err = fmt.Errorf("Could not find date format for %s", datestr)
+		}
+		return t, state, err
+	}
+	if p.layout != "" && layoutZoned(p.layout) {
+		// two offsets
+		return t, state, fmt.Errorf("Could not find date format for %s", datestr)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc), state, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,79 @@
+package dateparse
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseOffsets(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"2020-02-02 15:04:05 GMT+8", "2020-02-02 15:04:05 +0800 GMT+8"},
+		{"2020-02-02 15:04:05 UTC-03:30", "2020-02-02 15:04:05 -0330 UTC-03:30"},
+		{"2020-02-02 15:04:05 UTC+05", "2020-02-02 15:04:05 +0500 UTC+05"},
+		{"2020-02-02 15:04:05 A", "2020-02-02 15:04:05 +0100 A"},
+		{"2020-02-02 15:04:05 Q", "2020-02-02 15:04:05 -0400 Q"},
+		{"2020-02-02 15:04:05 Z", "2020-02-02 15:04:05 +0000 UTC"},
+		{"2020-02-02T15:04:05+08", "2020-02-02 15:04:05 +0800 +0800"},
+		{"2020-02-02T15:04:05-08:00:00", "2020-02-02 15:04:05 -0800 -0800"},
+		{"2020-02-02 15:04:05 +0800", "2020-02-02 15:04:05 +0800 +0800"},
+		{"02/02/2020 15:04 +08:00", "2020-02-02 15:04:00 +0800 +0800"},
+		{"Mon Jan 02 15:04:05 UTC+8 2006", "2006-01-02 15:04:05 +0800 UTC+8"},
+		{"March 3rd 2020 at 4:05pm GMT-5", "2020-03-03 16:05:00 -0500 GMT-5"},
+	}
+	for _, tc := range tests {
+		res, _, err := ParseAny(tc.in)
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+		if got := res.String(); got != tc.out {
+			t.Errorf("%q: got %s want %s", tc.in, got, tc.out)
+		}
+	}
+
+	for _, in := range []string{"2020-02-02 15:04:05 UTC+25", "2020-02-02 15:04:05 +123"} {
+		if res, _, err := ParseAny(in); err == nil {
+			t.Errorf("%q: expected error, got %v", in, res)
+		}
+	}
+
+	// errors quote the date string given, not what is left without the offset
+	p, err := NewParser(AllowFamilies(FamilySlash))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range []string{"2020-02-30 15:04:05 UTC+8", "2020-02-02 15:04:05 GMT+8 -0700"} {
+		for _, parse := range []func(string) (ParseResult, error){defaultParser.ParseAny, p.ParseAny} {
+			if _, err := parse(in); err == nil || !strings.Contains(err.Error(), in) {
+				t.Errorf("%q: got error %v", in, err)
+			}
+		}
+	}
+}
+
+func TestMilitaryZoneInText(t *testing.T) {
+	// a capital letter after the time that doesn't end the input is a word
+	res, rest, err := ParsePrefix("2017-07-19 03:21:51,123 W msg")
+	if err != nil || res.Time.Location() != time.UTC || rest != "W msg" {
+		t.Errorf("ParsePrefix: got %v %q %v", res.Time, rest, err)
+	}
+	res, rest, err = ParsePrefix("10:30 A started")
+	if err != nil || res.Time.Location() != time.UTC || rest != "A started" {
+		t.Errorf("ParsePrefix: got %v %q %v", res.Time, rest, err)
+	}
+	matches := FindAll("we meet at 10:30 I think")
+	if len(matches) != 1 || matches[0].Time.Location() != time.UTC || matches[0].End != len("we meet at 10:30") {
+		t.Errorf("FindAll: got %+v", matches)
+	}
+	pt, _, err := ParseAny("2020-02-02 15:04:05 A new version")
+	if want := time.Date(2020, 2, 2, 15, 4, 5, 0, time.UTC); err != nil || !pt.Equal(want) {
+		t.Errorf("ParseAny: got %v %v want %v", pt, err, want)
+	}
+	if pt, _, err := ParseAny("Mon Jan 02 15:04:05 A 2006"); err == nil {
+		t.Errorf("ParseAny: expected error, got %v", pt)
+	}
+}
@@ -4,11 +4,13 @@
 package dateparse
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 )
 
 //       _           _
@@ -69,6 +71,15 @@ const (
 	StateHowLongAgo
 	StateTimestamp
 	StateNow
+	StateAlphaWSDigit
+	StateAlphaWSDigitColon
+	StateAlphaWSDigitDash
+	StateDigitSlashAlpha
+	StateDigitPeriod
+	StateDigitColon
+	StateTimeOfDay
+	StateLongForm
+	StateCustom
 )
 
 const (
@@ -77,6 +88,58 @@ const (
 
 var (
 	shortDates = []string{"01/02/2006", "1/2/2006", "06/01/02", "01/02/06", "1/2/06"}
+	// timeOfDayLayouts are the times without a date of StateDigitColon.
+	timeOfDayLayouts = []string{"15:04", "15:04:05", "3:04 PM", "3:04PM", "3:04 pm", "3:04pm",
+		"3:04:05 PM", "3:04:05PM", "3:04:05 pm", "3:04:05pm"}
+	// dayMonthLayouts are the StateDigitAlpha dates not in digitAlphaLayout.
+	dayMonthLayouts = []string{"2 Jan", "2 January", "2 Jan 2006", "2 January 2006"}
+	// monthYearLayouts are the dates of StateAlphaWSDigit.
+	monthYearLayouts = []string{"Jan 2006", "January 2006", "Jan 2", "January 2"}
+	// commonLogLayouts are the apache/nginx dates of StateDigitSlashAlpha.
+	commonLogLayouts = []string{"02/Jan/2006:15:04:05 -0700", "02/Jan/2006:15:04:05", "02/Jan/2006"}
+
+	// slashLayouts are the layouts tried by the slash states with a time
+	// of day, for dates that start with the month and with the year.
+	slashLayouts = map[DateState]struct{ monthFirst, yearFirst []string }{
+		// 4/8/2014 22:05
+		// 04/08/2014 22:05
+		// 2014/4/8 22:05
+		// 2014/04/08 22:05
+		StateDigitSlashWSColon: {
+			[]string{"01/02/2006 15:04", "01/2/2006 15:04", "1/02/2006 15:04", "1/2/2006 15:04"},
+			[]string{"2006/01/02 15:04", "2006/1/2 15:04", "2006/01/2 15:04", "2006/1/02 15:04"},
+		},
+		// 4/8/2014 22:05 PM
+		// 04/08/2014 22:05 PM
+		// 04/08/2014 1:05 PM
+		// 2014/4/8 22:05 PM
+		// 2014/04/08 22:05 PM
+		StateDigitSlashWSColonAMPM: {
+			[]string{"01/02/2006 03:04 PM", "01/2/2006 03:04 PM", "1/02/2006 03:04 PM", "1/2/2006 03:04 PM",
+				"01/02/2006 3:04 PM", "01/2/2006 3:04 PM", "1/02/2006 3:04 PM", "1/2/2006 3:04 PM"},
+			[]string{"2006/01/02 03:04 PM", "2006/01/2 03:04 PM", "2006/1/02 03:04 PM", "2006/1/2 03:04 PM",
+				"2006/01/02 3:04 PM", "2006/01/2 3:04 PM", "2006/1/02 3:04 PM", "2006/1/2 3:04 PM"},
+		},
+		// 2014/07/10 06:55:38.156283
+		// 03/19/2012 10:11:59
+		// 3/1/2012 10:11:59
+		// 03/1/2012 10:11:59
+		// 3/01/2012 10:11:59
+		StateDigitSlashWSColonColon: {
+			[]string{"01/02/2006 15:04:05", "1/02/2006 15:04:05", "01/2/2006 15:04:05", "1/2/2006 15:04:05"},
+			[]string{"2006/01/02 15:04:05", "2006/1/02 15:04:05", "2006/01/2 15:04:05", "2006/1/2 15:04:05"},
+		},
+		// 2014/07/10 06:55:38.156283 PM
+		// 03/19/2012 10:11:59 PM
+		// 3/1/2012 10:11:59 PM
+		// 03/1/2012 10:11:59 PM
+		// 3/01/2012 10:11:59 PM
+		StateDigitSlashWSColonColonAMPM: {
+			[]string{"01/02/2006 03:04:05 PM", "1/02/2006 03:04:05 PM", "01/2/2006 03:04:05 PM", "1/2/2006 03:04:05 PM"},
+			[]string{"2006/01/02 03:04:05 PM", "2006/1/02 03:04:05 PM", "2006/01/2 03:04:05 PM", "2006/1/2 03:04:05 PM",
+				"2006/01/02 3:04:05 PM", "2006/1/02 3:04:05 PM", "2006/01/2 3:04:05 PM", "2006/1/2 3:04:05 PM"},
+		},
+	}
 )
 
 // ParseAny parse an unknown date format, detect the layout, parse.
@@ -123,468 +186,54 @@ func MustParse(datestr string) time.Time {
 	return t
 }
 
-func parse(layout, datestr string, loc *time.Location) (time.Time, error) {
-	if loc == nil {
-		return time.Parse(layout, datestr)
-	}
-	return time.ParseInLocation(layout, datestr, loc)
+func parseTime(datestr string, loc *time.Location) (time.Time, DateState, error) {
+	res, err := defaultParser.parse(datestr, loc)
+	return res.Time, res.State, err
 }
 
-func parseTime(datestr string, loc *time.Location) (time.Time, DateState, error) {
-	if strings.ToLower(datestr) == "now" {
-		if loc != nil {
-			return time.Now().In(loc), StateNow, nil
+func (p *parser) parseTime(datestr string) (time.Time, DateState, error) {
+	keyword := ""
+	switch len(datestr) {
+	case len("now"), len("noon"), len("midnight"):
+		keyword = strings.ToLower(datestr)
+	}
+	switch keyword {
+	case "now", "noon", "midnight":
+		if err := p.checkFamily(StateNow, datestr); err != nil {
+			return time.Time{}, StateNow, err
 		}
-		return time.Now(), StateNow, nil
+	}
+	switch keyword {
+	case "now":
+		p.precision = PrecisionSubsecond
+		if p.loc != nil {
+			return p.now().In(p.loc), StateNow, nil
+		}
+		return p.now(), StateNow, nil
+	case "noon":
+		p.precision = PrecisionMinute
+		return p.timeOfDay(TimeOfDay{Hour: 12}, StateTimeOfDay)
+	case "midnight":
+		p.precision = PrecisionMinute
+		return p.timeOfDay(TimeOfDay{}, StateTimeOfDay)
+	}
+	if tod, prec, ok := parseChineseTimeOfDay(datestr); ok {
+		// 下午3点
+		if err := p.checkFamily(StateTimeOfDay, datestr); err != nil {
+			return time.Time{}, StateTimeOfDay, err
+		}
+		p.precision = prec
+		return p.timeOfDay(tod, StateTimeOfDay)
 	}
 
-	state := StateStart
-
-	firstSlash := 0
-
-	// General strategy is to read rune by rune through the date looking for
-	// certain hints of what type of date we are dealing with.
-	// Hopefully we only need to read about 5 or 6 bytes before
-	// we figure it out and then attempt a parse
-iterRunes:
-	for i := 0; i < len(datestr); i++ {
-		r := rune(datestr[i])
-		// r, bytesConsumed := utf8.DecodeRuneInString(datestr[ri:])
-		// if bytesConsumed > 1 {
-		// 	ri += (bytesConsumed - 1)
-		// }
-
-		switch state {
-		case StateStart:
-			if unicode.IsDigit(r) {
-				state = StateDigit
-			} else if unicode.IsLetter(r) {
-				state = StateAlpha
-			}
-		case StateDigit: // starts digits
-			if unicode.IsDigit(r) {
-				continue
-			} else if unicode.IsLetter(r) {
-				state = StateDigitAlpha
-				continue
-			}
-			switch r {
-			case '-', '\u2212':
-				state = StateDigitDash
-			case '/':
-				state = StateDigitSlash
-				firstSlash = i
-			}
-		case StateDigitDash: // starts digit then dash 02-
-			// 2006-01-02T15:04:05Z07:00
-			// 2017-06-25T17:46:57.45706582-07:00
-			// 2006-01-02T15:04:05.999999999Z07:00
-			// 2006-01-02T15:04:05+0000
-			// 2012-08-03 18:31:59.257000000
-			// 2014-04-26 17:24:37.3186369
-			// 2017-01-27 00:07:31.945167
-			// 2016-03-14 00:00:00.000
-			// 2014-05-11 08:20:13,787
-			// 2017-07-19 03:21:51+00:00
-			// 2006-01-02
-			// 2013-04-01 22:43:22
-			// 2014-04-26 05:24:37 PM
-			// 2013-Feb-03
-			switch {
-			case r == ' ':
-				state = StateDigitDashWs
-			case r == 'T':
-				state = StateDigitDashT
-			default:
-				if unicode.IsLetter(r) {
-					state = StateDigitDashAlpha
-					break iterRunes
-				}
-			}
-		case StateDigitDashWs:
-			// 2013-04-01 22:43:22
-			// 2014-05-11 08:20:13,787
-			// stateDigitDashWsWs
-			//   2014-04-26 05:24:37 PM
-			//   2014-12-16 06:20:00 UTC
-			//   2015-02-18 00:12:00 +0000 UTC
-			//   2006-01-02 15:04:05 -0700
-			//   2006-01-02 15:04:05 -07:00
-			// stateDigitDashWsOffset
-			//   2017-07-19 03:21:51+00:00
-			// stateDigitDashWsPeriod
-			//   2014-04-26 17:24:37.3186369
-			//   2017-01-27 00:07:31.945167
-			//   2012-08-03 18:31:59.257000000
-			//   2016-03-14 00:00:00.000
-			//   stateDigitDashWsPeriodOffset
-			//     2017-01-27 00:07:31.945167 +0000
-			//     2016-03-14 00:00:00.000 +0000
-			//     stateDigitDashWsPeriodOffsetAlpha
-			//       2017-01-27 00:07:31.945167 +0000 UTC
-			//       2016-03-14 00:00:00.000 +0000 UTC
-			//   stateDigitDashWsPeriodAlpha
-			//     2014-12-16 06:20:00.000 UTC
-			switch r {
-			case ',':
-				if len(datestr) == len("2014-05-11 08:20:13,787") {
-					// go doesn't seem to parse this one natively?   or did i miss it?
-					t, err := parse("2006-01-02 03:04:05", datestr[:i], loc)
-					if err == nil {
-						ms, err := strconv.Atoi(datestr[i+1:])
-						if err == nil {
-							return time.Unix(0, t.UnixNano()+int64(ms)*1e6), StateDigitDashWs, nil
-						}
-					}
-					return t, StateDigitDashWs, err
-				}
-			case '-', '+':
-				state = StateDigitDashWsOffset
-			case '.':
-				state = StateDigitDashWsPeriod
-			case ' ':
-				state = StateDigitDashWsWs
-			}
-
-		case StateDigitDashWsWs:
-			// stateDigitDashWsWsAlpha
-			//   2014-12-16 06:20:00 UTC
-			//   stateDigitDashWsWsAMPMMaybe
-			//     2014-04-26 05:24:37 PM
-			// stateDigitDashWsWsOffset
-			//   2006-01-02 15:04:05 -0700
-			//   stateDigitDashWsWsOffsetColon
-			//     2006-01-02 15:04:05 -07:00
-			//     stateDigitDashWsWsOffsetColonAlpha
-			//       2015-02-18 00:12:00 +00:00 UTC
-			//   stateDigitDashWsWsOffsetAlpha
-			//     2015-02-18 00:12:00 +0000 UTC
-			switch r {
-			case 'A', 'P':
-				state = StateDigitDashWsWsAMPMMaybe
-			case '+', '-':
-				state = StateDigitDashWsWsOffset
-			default:
-				if unicode.IsLetter(r) {
-					// 2014-12-16 06:20:00 UTC
-					state = StateDigitDashWsWsAlpha
-					break iterRunes
-				}
-			}
-
-		case StateDigitDashWsWsAMPMMaybe:
-			if r == 'M' {
-				t, err := parse("2006-01-02 03:04:05 PM", datestr, loc)
-				return t, StateDigitDashWsWsAMPMMaybe, err
-			}
-			state = StateDigitDashWsWsAlpha
-
-		case StateDigitDashWsWsOffset:
-			// stateDigitDashWsWsOffset
-			//   2006-01-02 15:04:05 -0700
-			//   stateDigitDashWsWsOffsetColon
-			//     2006-01-02 15:04:05 -07:00
-			//     stateDigitDashWsWsOffsetColonAlpha
-			//       2015-02-18 00:12:00 +00:00 UTC
-			//   stateDigitDashWsWsOffsetAlpha
-			//     2015-02-18 00:12:00 +0000 UTC
-			if r == ':' {
-				state = StateDigitDashWsWsOffsetColon
-			} else if unicode.IsLetter(r) {
-				// 2015-02-18 00:12:00 +0000 UTC
-				state = StateDigitDashWsWsOffsetAlpha
-				break iterRunes
-			}
-
-		case StateDigitDashWsWsOffsetColon:
-			// stateDigitDashWsWsOffsetColon
-			//   2006-01-02 15:04:05 -07:00
-			//   stateDigitDashWsWsOffsetColonAlpha
-			//     2015-02-18 00:12:00 +00:00 UTC
-			if unicode.IsLetter(r) {
-				// 2015-02-18 00:12:00 +00:00 UTC
-				state = StateDigitDashWsWsOffsetColonAlpha
-				break iterRunes
-			}
-
-		case StateDigitDashWsPeriod:
-			// 2014-04-26 17:24:37.3186369
-			// 2017-01-27 00:07:31.945167
-			// 2012-08-03 18:31:59.257000000
-			// 2016-03-14 00:00:00.000
-			// stateDigitDashWsPeriodOffset
-			//   2017-01-27 00:07:31.945167 +0000
-			//   2016-03-14 00:00:00.000 +0000
-			//   stateDigitDashWsPeriodOffsetAlpha
-			//     2017-01-27 00:07:31.945167 +0000 UTC
-			//     2016-03-14 00:00:00.000 +0000 UTC
-			// stateDigitDashWsPeriodAlpha
-			//   2014-12-16 06:20:00.000 UTC
-			if unicode.IsLetter(r) {
-				// 2014-12-16 06:20:00.000 UTC
-				state = StateDigitDashWsPeriodAlpha
-				break iterRunes
-			} else if r == '+' || r == '-' {
-				state = StateDigitDashWsPeriodOffset
-			}
-		case StateDigitDashWsPeriodOffset:
-			// 2017-01-27 00:07:31.945167 +0000
-			// 2016-03-14 00:00:00.000 +0000
-			// stateDigitDashWsPeriodOffsetAlpha
-			//   2017-01-27 00:07:31.945167 +0000 UTC
-			//   2016-03-14 00:00:00.000 +0000 UTC
-			if unicode.IsLetter(r) {
-				// 2014-12-16 06:20:00.000 UTC
-				// 2017-01-27 00:07:31.945167 +0000 UTC
-				// 2016-03-14 00:00:00.000 +0000 UTC
-				state = StateDigitDashWsPeriodOffsetAlpha
-				break iterRunes
-			}
-		case StateDigitDashT: // starts digit then dash 02-  then T
-			// stateDigitDashT
-			// 2006-01-02T15:04:05
-			// stateDigitDashTZ
-			// 2006-01-02T15:04:05.999999999Z
-			// 2006-01-02T15:04:05.99999999Z
-			// 2006-01-02T15:04:05.9999999Z
-			// 2006-01-02T15:04:05.999999Z
-			// 2006-01-02T15:04:05.99999Z
-			// 2006-01-02T15:04:05.9999Z
-			// 2006-01-02T15:04:05.999Z
-			// 2006-01-02T15:04:05.99Z
-			// 2009-08-12T22:15Z
-			// stateDigitDashTZDigit
-			// 2006-01-02T15:04:05.999999999Z07:00
-			// 2006-01-02T15:04:05Z07:00
-			// With another dash aka time-zone at end
-			// stateDigitDashTOffset
-			//   stateDigitDashTOffsetColon
-			//     2017-06-25T17:46:57.45706582-07:00
-			//     2017-06-25T17:46:57+04:00
-			// 2006-01-02T15:04:05+0000
-			switch r {
-			case '-', '+':
-				state = StateDigitDashTOffset
-			case 'Z':
-				state = StateDigitDashTZ
-			}
-		case StateDigitDashTZ:
-			if unicode.IsDigit(r) {
-				state = StateDigitDashTZDigit
-			}
-		case StateDigitDashTOffset:
-			if r == ':' {
-				state = StateDigitDashTOffsetColon
-			}
-		case StateDigitSlash: // starts digit then slash 02/
-			// 2014/07/10 06:55:38.156283
-			// 03/19/2012 10:11:59
-			// 04/2/2014 03:00:37
-			// 3/1/2012 10:11:59
-			// 4/8/2014 22:05
-			// 3/1/2014
-			// 10/13/2014
-			// 01/02/2006
-			// 1/2/06
-			if unicode.IsDigit(r) || r == '/' {
-				continue
-			}
-			switch r {
-			case ' ':
-				state = StateDigitSlashWS
-			}
-		case StateDigitSlashWS: // starts digit then slash 02/ more digits/slashes then whitespace
-			// 2014/07/10 06:55:38.156283
-			// 03/19/2012 10:11:59
-			// 04/2/2014 03:00:37
-			// 3/1/2012 10:11:59
-			// 4/8/2014 22:05
-			switch r {
-			case ':':
-				state = StateDigitSlashWSColon
-			}
-		case StateDigitSlashWSColon: // starts digit then slash 02/ more digits/slashes then whitespace
-			// 2014/07/10 06:55:38.156283
-			// 03/19/2012 10:11:59
-			// 04/2/2014 03:00:37
-			// 3/1/2012 10:11:59
-			// 4/8/2014 22:05
-			// 3/1/2012 10:11:59 AM
-			switch r {
-			case ':':
-				state = StateDigitSlashWSColonColon
-			case 'A', 'P':
-				state = StateDigitSlashWSColonAMPM
-			}
-		case StateDigitSlashWSColonColon: // starts digit then slash 02/ more digits/slashes then whitespace
-			// 2014/07/10 06:55:38.156283
-			// 03/19/2012 10:11:59
-			// 04/2/2014 03:00:37
-			// 3/1/2012 10:11:59
-			// 4/8/2014 22:05
-			// 3/1/2012 10:11:59 AM
-			switch r {
-			case 'A', 'P':
-				state = StateDigitSlashWSColonColonAMPM
-			}
-		case StateDigitAlpha:
-			// 12 Feb 2006, 19:17
-			// 12 Feb 2006, 19:17:22
-			switch {
-			case len(datestr) == len("02 Jan 2006, 15:04"):
-				t, err := parse("02 Jan 2006, 15:04", datestr, loc)
-				return t, StateDigitAlpha, err
-			case len(datestr) == len("02 Jan 2006, 15:04:05"):
-				t, err := parse("02 Jan 2006, 15:04:05", datestr, loc)
-				return t, StateDigitAlpha, err
-			case len(datestr) == len("2006年01月02日"):
-				t, err := parse("2006年01月02日", datestr, loc)
-				return t, StateDigitAlpha, err
-			case len(datestr) == len("2006年01月02日 15:04"):
-				t, err := parse("2006年01月02日 15:04", datestr, loc)
-				return t, StateDigitAlpha, err
-			case strings.Contains(datestr, "ago"):
-				state = StateHowLongAgo
-			}
-		case StateAlpha: // starts alpha
-			// stateAlphaWS
-			//  Mon Jan _2 15:04:05 2006
-			//  Mon Jan _2 15:04:05 MST 2006
-			//  Mon Jan 02 15:04:05 -0700 2006
-			//  Mon Aug 10 15:44:11 UTC+0100 2015
-			//  Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
-			//  stateAlphaWSDigitComma
-			//    May 8, 2009 5:57:51 PM
-			//
-			// stateWeekdayComma
-			//   Monday, 02-Jan-06 15:04:05 MST
-			//   stateWeekdayCommaOffset
-			//     Monday, 02 Jan 2006 15:04:05 -0700
-			//     Monday, 02 Jan 2006 15:04:05 +0100
-			// stateWeekdayAbbrevComma
-			//   Mon, 02-Jan-06 15:04:05 MST
-			//   Mon, 02 Jan 2006 15:04:05 MST
-			//   stateWeekdayAbbrevCommaOffset
-			//     Mon, 02 Jan 2006 15:04:05 -0700
-			//     Thu, 13 Jul 2017 08:58:40 +0100
-			//     stateWeekdayAbbrevCommaOffsetZone
-			//       Tue, 11 Jul 2017 16:28:13 +0200 (CEST)
-			switch {
-			case unicode.IsLetter(r):
-				continue
-			case r == ' ':
-				state = StateAlphaWS
-			case r == ',':
-				if i == 3 {
-					state = StateWeekdayAbbrevComma
-				} else {
-					state = StateWeekdayComma
-				}
-			}
-		case StateWeekdayComma: // Starts alpha then comma
-			// Mon, 02-Jan-06 15:04:05 MST
-			// Mon, 02 Jan 2006 15:04:05 MST
-			// stateWeekdayCommaOffset
-			//   Monday, 02 Jan 2006 15:04:05 -0700
-			//   Monday, 02 Jan 2006 15:04:05 +0100
-			switch {
-			case r == '-':
-				if i < 15 {
-					t, err := parse("Monday, 02-Jan-06 15:04:05 MST", datestr, loc)
-					return t, StateWeekdayComma, err
-				}
-				state = StateWeekdayCommaOffset
-			case r == '+':
-				state = StateWeekdayCommaOffset
-			}
-		case StateWeekdayAbbrevComma: // Starts alpha then comma
-			// Mon, 02-Jan-06 15:04:05 MST
-			// Mon, 02 Jan 2006 15:04:05 MST
-			// stateWeekdayAbbrevCommaOffset
-			//   Mon, 02 Jan 2006 15:04:05 -0700
-			//   Thu, 13 Jul 2017 08:58:40 +0100
-			//   stateWeekdayAbbrevCommaOffsetZone
-			//     Tue, 11 Jul 2017 16:28:13 +0200 (CEST)
-			switch {
-			case r == '-':
-				if i < 15 {
-					t, err := parse("Mon, 02-Jan-06 15:04:05 MST", datestr, loc)
-					return t, StateWeekdayAbbrevComma, err
-				}
-				state = StateWeekdayAbbrevCommaOffset
-			case r == '+':
-				state = StateWeekdayAbbrevCommaOffset
-			}
-
-		case StateWeekdayAbbrevCommaOffset:
-			// stateWeekdayAbbrevCommaOffset
-			//   Mon, 02 Jan 2006 15:04:05 -0700
-			//   Thu, 13 Jul 2017 08:58:40 +0100
-			//   stateWeekdayAbbrevCommaOffsetZone
-			//     Tue, 11 Jul 2017 16:28:13 +0200 (CEST)
-			if r == '(' {
-				state = StateWeekdayAbbrevCommaOffsetZone
-			}
-
-		case StateAlphaWS: // Starts alpha then whitespace
-			// Mon Jan _2 15:04:05 2006
-			// Mon Jan _2 15:04:05 MST 2006
-			// Mon Jan 02 15:04:05 -0700 2006
-			// Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
-			// Mon Aug 10 15:44:11 UTC+0100 2015
-			switch {
-			case unicode.IsLetter(r):
-				state = StateAlphaWSAlpha
-			case unicode.IsDigit(r):
-				state = StateAlphaWSDigitComma
-			}
+	state, stopped := p.scan(datestr)
+	p.fraction = p.toks.fraction(datestr)
+	// the width of the year or month before the first slash of 2006/01/02
+	// and 01/02/2006
+	firstSlash := p.toks.list[0].end
 
-		case StateAlphaWSDigitComma: // Starts Alpha, whitespace, digit, comma
-			// May 8, 2009 5:57:51 PM
-			// May 8, 2009
-			if len(datestr) == len("May 8, 2009") {
-				t, err := parse("Jan 2, 2006", datestr, loc)
-				return t, StateAlphaWSDigitComma, err
-			}
-			t, err := parse("Jan 2, 2006 3:04:05 PM", datestr, loc)
-			return t, StateAlphaWSDigitComma, err
-
-		case StateAlphaWSAlpha: // Alpha, whitespace, alpha
-			// Mon Jan _2 15:04:05 2006
-			// Mon Jan 02 15:04:05 -0700 2006
-			// Mon Jan _2 15:04:05 MST 2006
-			// Mon Aug 10 15:44:11 UTC+0100 2015
-			// Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
-			if r == ':' {
-				state = StateAlphaWSAlphaColon
-			}
-		case StateAlphaWSAlphaColon: // Alpha, whitespace, alpha, :
-			// Mon Jan _2 15:04:05 2006
-			// Mon Jan 02 15:04:05 -0700 2006
-			// Mon Jan _2 15:04:05 MST 2006
-			// Mon Aug 10 15:44:11 UTC+0100 2015
-			// Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
-			if unicode.IsLetter(r) {
-				state = StateAlphaWSAlphaColonAlpha
-			} else if r == '-' || r == '+' {
-				state = StateAlphaWSAlphaColonOffset
-			}
-		case StateAlphaWSAlphaColonAlpha: // Alpha, whitespace, alpha, :, alpha
-			// Mon Jan _2 15:04:05 MST 2006
-			// Mon Aug 10 15:44:11 UTC+0100 2015
-			// Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
-			if r == '+' {
-				state = StateAlphaWSAlphaColonAlphaOffset
-			}
-		case StateAlphaWSAlphaColonAlphaOffset: // Alpha, whitespace, alpha, : , alpha, offset, ?
-			// Mon Aug 10 15:44:11 UTC+0100 2015
-			// Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
-			if unicode.IsLetter(r) {
-				state = StateAlphaWSAlphaColonAlphaOffsetAlpha
-			}
-		default:
-			break iterRunes
-		}
+	if err := p.checkFamily(state, datestr); err != nil {
+		return time.Time{}, state, err
 	}
 
 	switch state {
@@ -601,20 +250,23 @@ iterRunes:
 		if len(datestr) > len("1499979795437000") {
 			if nanoSecs, err := strconv.ParseInt(datestr, 10, 64); err == nil {
 				t = time.Unix(0, nanoSecs)
+				p.precision = PrecisionSubsecond
 			}
 		} else if len(datestr) > len("1499979795437") {
 			if microSecs, err := strconv.ParseInt(datestr, 10, 64); err == nil {
 				t = time.Unix(0, microSecs*1000)
+				p.precision = PrecisionSubsecond
 			}
 		} else if len(datestr) > len("1332151919") {
 			if miliSecs, err := strconv.ParseInt(datestr, 10, 64); err == nil {
 				t = time.Unix(0, miliSecs*1000*1000)
+				p.precision = PrecisionSubsecond
 			}
 		} else if len(datestr) == len("20140601") {
-			t, err := parse("20060102", datestr, loc)
+			t, err := p.parse("20060102", datestr)
 			return t, StateDigit, err
 		} else if len(datestr) == len("2014") {
-			t, err := parse("2006", datestr, loc)
+			t, err := p.parse("2006", datestr)
 			return t, StateDigit, err
 		}
 		if t.IsZero() {
@@ -624,34 +276,103 @@ iterRunes:
 					// nothing before unix-epoch
 				} else {
 					t = time.Unix(secs, 0)
+					p.precision = PrecisionSecond
 				}
 			}
 		}
 		if !t.IsZero() {
-			if loc == nil {
+			if p.loc == nil {
 				return t, StateTimestamp, nil
 			}
-			return t.In(loc), StateTimestamp, nil
+			return t.In(p.loc), StateTimestamp, nil
+		}
+
+	case StateDigitColon:
+		// a time of day without a date
+		// 15:04
+		// 15:04:05.123
+		// 3:04 PM
+		// 3:04pm
+		for _, layout := range timeOfDayLayouts {
+			if t, err := p.parse(layout, datestr); err == nil {
+				return p.timeOfDay(TimeOfDay{t.Hour(), t.Minute(), t.Second(), t.Nanosecond()}, StateDigitColon)
+			}
+		}
+
+	case StateDigitPeriod:
+		// unix seconds with a fraction, journald short-unix
+		//  1332151919.123456
+		dot := strings.IndexByte(datestr, '.')
+		frac := datestr[dot+1:]
+		if dot != len("1332151919") || len(frac) == 0 || len(frac) > 9 {
+			break
+		}
+		secs, err := strconv.ParseInt(datestr[:dot], 10, 64)
+		if err != nil {
+			break
 		}
+		nanos, err := strconv.ParseUint(frac, 10, 32)
+		if err != nil {
+			break
+		}
+		for n := len(frac); n < 9; n++ {
+			nanos *= 10
+		}
+		t := time.Unix(secs, int64(nanos))
+		p.precision = PrecisionSubsecond
+		if p.loc == nil {
+			return t, StateTimestamp, nil
+		}
+		return t.In(p.loc), StateTimestamp, nil
 
 	case StateDigitDash: // starts digit then dash 02-
 		// 2006-01-02
 		// 2006-01
-		if len(datestr) == len("2014-04-26") {
-			t, err := parse("2006-01-02", datestr, loc)
-			return t, StateDigitDash, err
-		} else if len(datestr) == len("2014-04") {
-			t, err := parse("2006-01", datestr, loc)
+		// 2006-1-2
+		year, month, day := p.toks.digits(0), p.toks.digits(1), p.toks.digits(2)
+		if year.end-year.start == 4 && month.kind == tokDigits && p.toks.count(datestr, '-') == p.toks.n/2 {
+			layout := numLayout(month, "2006-1", "2006-01")
+			if day.kind == tokDigits {
+				layout = ymdLayout('-', month, day)
+			}
+			t, err := p.parse(layout, datestr)
 			return t, StateDigitDash, err
 		}
+	case StateDigitAlpha:
+		// 12 Feb 2006, 19:17
+		// 12 Feb 2006, 19:17:22
+		// 2006年01月02日
+		// 2006年01月02日 15:04
+		// 2006年01月02日下午3点
+		if t, ok, err := p.parseChineseDateTime(datestr); ok {
+			return t, StateDigitAlpha, err
+		}
+		if layout := p.digitAlphaLayout(datestr); layout != "" {
+			if t, err := p.parse(layout, datestr); err == nil {
+				return t, StateDigitAlpha, nil
+			}
+		}
+		// 3 Feb
+		// 3 February
+		// 3 Feb 2020
+		// 3 February 2020
+		for _, layout := range dayMonthLayouts {
+			if t, err := p.parse(layout, datestr); err == nil {
+				if !layoutHas(layout, elemYear) {
+					t, err = p.inferYear(t)
+				}
+				return t, StateDigitAlpha, err
+			}
+		}
+
 	case StateDigitDashAlpha:
 		// 2013-Feb-03
-		t, err := parse("2006-Jan-02", datestr, loc)
+		t, err := p.parse("2006-Jan-02", datestr)
 		return t, StateDigitDashAlpha, err
 
 	case StateDigitDashTOffset:
 		// 2006-01-02T15:04:05+0000
-		t, err := parse("2006-01-02T15:04:05-0700", datestr, loc)
+		t, err := p.parse("2006-01-02T15:04:05-0700", datestr)
 		return t, StateDigitDashTOffset, err
 
 	case StateDigitDashTOffsetColon:
@@ -664,13 +385,13 @@ iterRunes:
 		// 2006-01-02T15:04:05.999-07:00
 		// 2006-01-02T15:04:05+07:00
 		// 2006-01-02T15:04:05-07:00
-		t, err := parse("2006-01-02T15:04:05-07:00", datestr, loc)
+		t, err := p.parse("2006-01-02T15:04:05-07:00", datestr)
 		return t, StateDigitDashTOffsetColon, err
 
 	case StateDigitDashT: // starts digit then dash 02-  then T
 		// 2006-01-02T15:04:05.999999
 		// 2006-01-02T15:04:05.999999
-		t, err := parse("2006-01-02T15:04:05", datestr, loc)
+		t, err := p.parse("2006-01-02T15:04:05", datestr)
 		return t, StateDigitDashT, err
 
 	case StateDigitDashTZDigit:
@@ -691,61 +412,102 @@ iterRunes:
 		// 2006-01-02T15:04:05.999Z
 		// 2006-01-02T15:04:05.99Z
 		// 2009-08-12T22:15Z  -- No seconds/milliseconds
-		switch len(datestr) {
-		case len("2009-08-12T22:15Z"):
-			t, err := parse("2006-01-02T15:04Z", datestr, loc)
-			return t, StateDigitDashTZ, err
-		default:
-			t, err := parse("2006-01-02T15:04:05Z", datestr, loc)
+		if p.toks.count(datestr, ':') == 1 {
+			t, err := p.parse("2006-01-02T15:04Z", datestr)
 			return t, StateDigitDashTZ, err
 		}
+		t, err := p.parse("2006-01-02T15:04:05Z", datestr)
+		return t, StateDigitDashTZ, err
 	case StateDigitDashWs: // starts digit then dash 02-  then whitespace   1 << 2  << 5 + 3
 		// 2013-04-01 22:43:22
-		t, err := parse("2006-01-02 15:04:05", datestr, loc)
+		// 2014-05-11 08:20:13,787
+		if n := p.toks.n; n > 2 && p.toks.list[n-2].kind == tokPunct && datestr[p.toks.list[n-2].start] == ',' &&
+			p.toks.list[n-1].kind == tokDigits && p.toks.list[n-1].end-p.toks.list[n-1].start <= 9 {
+			// log4j and python logging put a comma before the milliseconds
+			comma, frac := p.toks.list[n-2].start, p.toks.list[n-1]
+			t, err := p.parse("2006-01-02 15:04:05", datestr[:comma])
+			if err != nil {
+				return t, StateDigitDashWs, err
+			}
+			nanos, _ := strconv.Atoi(datestr[frac.start:frac.end])
+			for w := frac.end - frac.start; w < 9; w++ {
+				nanos *= 10
+			}
+			p.precision = PrecisionSubsecond
+			return t.Add(time.Duration(nanos)), StateDigitDashWs, nil
+		}
+		t, err := p.parse("2006-01-02 15:04:05", datestr)
 		return t, StateDigitDashWs, err
 
+	case StateDigitDashWsWsAMPMMaybe:
+		// 2014-04-26 05:24:37 PM
+		if stopped {
+			t, err := p.parse("2006-01-02 03:04:05 PM", datestr)
+			return t, StateDigitDashWsWsAMPMMaybe, err
+		}
+
 	case StateDigitDashWsWsOffset:
 		// 2006-01-02 15:04:05 -0700
-		t, err := parse("2006-01-02 15:04:05 -0700", datestr, loc)
+		t, err := p.parse("2006-01-02 15:04:05 -0700", datestr)
 		return t, StateDigitDashWsWsOffset, err
 
 	case StateDigitDashWsWsOffsetColon:
 		// 2006-01-02 15:04:05 -07:00
-		t, err := parse("2006-01-02 15:04:05 -07:00", datestr, loc)
+		t, err := p.parse("2006-01-02 15:04:05 -07:00", datestr)
 		return t, StateDigitDashWsWsOffsetColon, err
 
 	case StateDigitDashWsWsOffsetAlpha:
 		// 2015-02-18 00:12:00 +0000 UTC
-		t, err := parse("2006-01-02 15:04:05 -0700 UTC", datestr, loc)
+		t, err := p.parse("2006-01-02 15:04:05 -0700 UTC", datestr)
+		if err == nil {
+			return t, StateDigitDashWsWsOffsetAlpha, nil
+		}
+		t, err = p.parse("2006-01-02 15:04:05 +0000 GMT", datestr)
 		if err == nil {
 			return t, StateDigitDashWsWsOffsetAlpha, nil
 		}
-		t, err = parse("2006-01-02 15:04:05 +0000 GMT", datestr, loc)
-		return t, StateDigitDashWsWsOffsetAlpha, nil
+		// 2015-02-18 00:12:00 -0500 EST
+		t, err = p.parse("2006-01-02 15:04:05 -0700 MST", datestr)
+		return t, StateDigitDashWsWsOffsetAlpha, err
 
 	case StateDigitDashWsWsOffsetColonAlpha:
 		// 2015-02-18 00:12:00 +00:00 UTC
-		t, err := parse("2006-01-02 15:04:05 -07:00 UTC", datestr, loc)
+		t, err := p.parse("2006-01-02 15:04:05 -07:00 UTC", datestr)
 		return t, StateDigitDashWsWsOffsetColonAlpha, err
 
 	case StateDigitDashWsOffset:
 		// 2017-07-19 03:21:51+00:00
-		t, err := parse("2006-01-02 15:04:05-07:00", datestr, loc)
+		t, err := p.parse("2006-01-02 15:04:05-07:00", datestr)
 		return t, StateDigitDashWsOffset, err
 
 	case StateDigitDashWsWsAlpha:
 		// 2014-12-16 06:20:00 UTC
-		t, err := parse("2006-01-02 15:04:05 UTC", datestr, loc)
+		t, err := p.parse("2006-01-02 15:04:05 UTC", datestr)
 		if err == nil {
 			return t, StateDigitDashWsWsAlpha, nil
 		}
-		t, err = parse("2006-01-02 15:04:05 GMT", datestr, loc)
+		t, err = p.parse("2006-01-02 15:04:05 GMT", datestr)
 		if err == nil {
 			return t, StateDigitDashWsWsAlpha, nil
 		}
-		if len(datestr) > len("2006-01-02 03:04:05") {
-			t, err = parse("2006-01-02 03:04:05", datestr[:len("2006-01-02 03:04:05")], loc)
+		// 2014-12-16 06:20:00 CST
+		t, err = p.parse("2006-01-02 15:04:05 MST", datestr)
+		if err == nil || errors.Is(err, ErrAmbiguousZone) {
+			return t, StateDigitDashWsWsAlpha, err
+		}
+		if k := p.toks.index(datestr, ':'); !p.strict && k >= 0 && k+3 < p.toks.n {
+			// 2014-12-16 06:20:00 and something else
+			end := int(p.toks.list[k+3].end)
+			if t, zoned, err := p.parseZoneThenText(datestr, end); zoned {
+				return t, StateDigitDashWsWsAlpha, err
+			}
+			if looksLikeOffset(datestr[end:]) {
+				// dropping an offset gives the wrong instant
+				break
+			}
+			t, err = p.parse("2006-01-02 15:04:05", datestr[:end])
 			if err == nil {
+				p.ignore(datestr[end:])
 				return t, StateDigitDashWsWsAlpha, nil
 			}
 		}
@@ -755,7 +517,7 @@ iterRunes:
 		// 2014-04-26 17:24:37.3186369
 		// 2017-01-27 00:07:31.945167
 		// 2016-03-14 00:00:00.000
-		t, err := parse("2006-01-02 15:04:05", datestr, loc)
+		t, err := p.parse("2006-01-02 15:04:05", datestr)
 		return t, StateDigitDashWsPeriod, err
 
 	case StateDigitDashWsPeriodAlpha:
@@ -763,7 +525,12 @@ iterRunes:
 		// 2014-04-26 17:24:37.3186369 UTC
 		// 2017-01-27 00:07:31.945167 UTC
 		// 2016-03-14 00:00:00.000 UTC
-		t, err := parse("2006-01-02 15:04:05 UTC", datestr, loc)
+		t, err := p.parse("2006-01-02 15:04:05 UTC", datestr)
+		if err == nil {
+			return t, StateDigitDashWsPeriodAlpha, nil
+		}
+		// 2016-03-14 00:00:00.000 EST
+		t, err = p.parse("2006-01-02 15:04:05 MST", datestr)
 		return t, StateDigitDashWsPeriodAlpha, err
 
 	case StateDigitDashWsPeriodOffset:
@@ -771,7 +538,7 @@ iterRunes:
 		// 2014-04-26 17:24:37.3186369 +0000
 		// 2017-01-27 00:07:31.945167 +0000
 		// 2016-03-14 00:00:00.000 +0000
-		t, err := parse("2006-01-02 15:04:05 -0700", datestr, loc)
+		t, err := p.parse("2006-01-02 15:04:05 -0700", datestr)
 		return t, StateDigitDashWsPeriodOffset, err
 
 	case StateDigitDashWsPeriodOffsetAlpha:
@@ -779,36 +546,88 @@ iterRunes:
 		// 2014-04-26 17:24:37.3186369 +0000 UTC
 		// 2017-01-27 00:07:31.945167 +0000 UTC
 		// 2016-03-14 00:00:00.000 +0000 UTC
-		t, err := parse("2006-01-02 15:04:05 -0700 UTC", datestr, loc)
+		t, err := p.parse("2006-01-02 15:04:05 -0700 UTC", datestr)
 		return t, StateDigitDashWsPeriodOffsetAlpha, err
 
+	case StateAlphaWSDigit:
+		// Feb 2020
+		// February 2020
+		// Feb 3
+		// February 3
+		for _, layout := range monthYearLayouts {
+			if t, err := p.parse(layout, datestr); err == nil {
+				if !layoutHas(layout, elemYear) {
+					t, err = p.inferYear(t)
+				}
+				return t, StateAlphaWSDigit, err
+			}
+		}
+
+	case StateAlphaWSDigitComma:
+		// May 8, 2009
+		// May 8, 2009 5:57:51 PM
+		// September 17, 2012 22:09
+		if p.toks.n > 0 && p.toks.list[0].kind == tokAlpha {
+			layout := monthLayout(p.toks.list[0]) + " 2, 2006"
+			if clock := p.toks.clockLayout(datestr, 0); clock != "" {
+				layout += " " + clock
+			}
+			if t, err := p.parse(layout, datestr); err == nil {
+				return t, StateAlphaWSDigitComma, nil
+			}
+		}
+
+	case StateAlphaWSDigitColon:
+		// syslog (rfc3164) and journald have no year, it is inferred
+		// from the reference clock.
+		// Jan  2 15:04:05
+		// Jan 02 15:04:05.000
+		// Jan 02 15:04:05.000000
+		t, err := p.parse(time.Stamp, datestr)
+		if err == nil {
+			t, err = p.inferYear(t)
+		}
+		return t, StateAlphaWSDigitColon, err
+
+	case StateAlphaWSDigitDash:
+		// journald short-full
+		// Mon 2006-01-02 15:04:05 MST
+		t, err := p.parse("Mon 2006-01-02 15:04:05 MST", datestr)
+		return t, StateAlphaWSDigitDash, err
+
 	case StateAlphaWSAlphaColon:
 		// Mon Jan _2 15:04:05 2006
-		t, err := parse(time.ANSIC, datestr, loc)
+		t, err := p.parse(time.ANSIC, datestr)
 		return t, StateAlphaWSAlphaColon, err
 
 	case StateAlphaWSAlphaColonOffset:
 		// Mon Jan 02 15:04:05 -0700 2006
-		t, err := parse(time.RubyDate, datestr, loc)
+		t, err := p.parse(time.RubyDate, datestr)
 		return t, StateAlphaWSAlphaColonOffset, err
 
 	case StateAlphaWSAlphaColonAlpha:
 		// Mon Jan _2 15:04:05 MST 2006
-		t, err := parse(time.UnixDate, datestr, loc)
+		t, err := p.parse(time.UnixDate, datestr)
 		return t, StateAlphaWSAlphaColonAlpha, err
 
 	case StateAlphaWSAlphaColonAlphaOffset:
 		// Mon Aug 10 15:44:11 UTC+0100 2015
-		t, err := parse("Mon Jan 02 15:04:05 MST-0700 2006", datestr, loc)
+		t, err := p.parse("Mon Jan 02 15:04:05 MST-0700 2006", datestr)
 		return t, StateAlphaWSAlphaColonAlphaOffset, err
 
 	case StateAlphaWSAlphaColonAlphaOffsetAlpha:
 		// Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
-		if len(datestr) > len("Mon Jan 02 2006 15:04:05 MST-0700") {
+		if k := p.toks.index(datestr, '+'); k >= 0 && k+1 < p.toks.n && p.toks.list[k+1].kind == tokDigits {
 			// What effing time stamp is this?
 			// Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
-			dateTmp := datestr[:33]
-			t, err := parse("Mon Jan 02 2006 15:04:05 MST-0700", dateTmp, loc)
+			end := int(p.toks.list[k+1].end)
+			if end == len(datestr) || (p.strict && !isZoneComment(datestr[end:])) {
+				break
+			}
+			t, err := p.parse("Mon Jan 02 2006 15:04:05 MST-0700", datestr[:end])
+			if err == nil {
+				p.ignore(datestr[end:])
+			}
 			return t, StateAlphaWSAlphaColonAlphaOffsetAlpha, err
 		}
 	case StateDigitSlash: // starts digit then slash 02/ (but nothing else)
@@ -816,125 +635,91 @@ iterRunes:
 		// 10/13/2014
 		// 01/02/2006
 		// 2014/10/13
+		// 2014/10
+		// 10/2014
+		if strings.Count(datestr, "/") == 1 {
+			layout := "1/2006"
+			if firstSlash == 4 {
+				layout = "2006/1"
+			}
+			t, err := p.parse(layout, datestr)
+			return t, StateDigitSlash, err
+		}
 		if firstSlash == 4 {
-			if len(datestr) == len("2006/01/02") {
-				t, err := parse("2006/01/02", datestr, loc)
-				return t, StateDigitSlash, err
-			}
-			t, err := parse("2006/1/2", datestr, loc)
+			t, err := p.parse(ymdLayout('/', p.toks.digits(1), p.toks.digits(2)), datestr)
 			return t, StateDigitSlash, err
 		}
 		for _, parseFormat := range shortDates {
-			if t, err := parse(parseFormat, datestr, loc); err == nil {
+			if t, err := p.parse(parseFormat, datestr); err == nil {
 				return t, StateDigitSlash, nil
 			}
 		}
 
-	case StateDigitSlashWSColon: // starts digit then slash 02/ more digits/slashes then whitespace
-		// 4/8/2014 22:05
-		// 04/08/2014 22:05
-		// 2014/4/8 22:05
-		// 2014/04/08 22:05
-
-		if firstSlash == 4 {
-			for _, layout := range []string{"2006/01/02 15:04", "2006/1/2 15:04", "2006/01/2 15:04", "2006/1/02 15:04"} {
-				if t, err := parse(layout, datestr, loc); err == nil {
-					return t, StateDigitSlashWSColon, nil
-				}
-			}
-		} else {
-			for _, layout := range []string{"01/02/2006 15:04", "01/2/2006 15:04", "1/02/2006 15:04", "1/2/2006 15:04"} {
-				if t, err := parse(layout, datestr, loc); err == nil {
-					return t, StateDigitSlashWSColon, nil
-				}
+	case StateDigitSlashAlpha:
+		// apache/nginx common log format
+		// 02/Jan/2006:15:04:05 -0700
+		// 02/Jan/2006:15:04:05
+		// 02/Jan/2006
+		for _, layout := range commonLogLayouts {
+			if t, err := p.parse(layout, datestr); err == nil {
+				return t, StateDigitSlashAlpha, nil
 			}
 		}
 
-	case StateDigitSlashWSColonAMPM: // starts digit then slash 02/ more digits/slashes then whitespace
-		// 4/8/2014 22:05 PM
-		// 04/08/2014 22:05 PM
-		// 04/08/2014 1:05 PM
-		// 2014/4/8 22:05 PM
+	case StateDigitSlashWSColon, StateDigitSlashWSColonAMPM, StateDigitSlashWSColonColon, StateDigitSlashWSColonColonAMPM:
+		// starts digit then slash 02/ more digits/slashes then whitespace,
+		// see slashLayouts
+		// 4/8/2014 22:05
 		// 2014/04/08 22:05 PM
-
-		if firstSlash == 4 {
-			for _, layout := range []string{"2006/01/02 03:04 PM", "2006/01/2 03:04 PM", "2006/1/02 03:04 PM", "2006/1/2 03:04 PM",
-				"2006/01/02 3:04 PM", "2006/01/2 3:04 PM", "2006/1/02 3:04 PM", "2006/1/2 3:04 PM"} {
-				if t, err := parse(layout, datestr, loc); err == nil {
-					return t, StateDigitSlashWSColonAMPM, nil
-				}
-			}
-		} else {
-			for _, layout := range []string{"01/02/2006 03:04 PM", "01/2/2006 03:04 PM", "1/02/2006 03:04 PM", "1/2/2006 03:04 PM",
-				"01/02/2006 3:04 PM", "01/2/2006 3:04 PM", "1/02/2006 3:04 PM", "1/2/2006 3:04 PM"} {
-				if t, err := parse(layout, datestr, loc); err == nil {
-					return t, StateDigitSlashWSColonAMPM, nil
-				}
-
-			}
-		}
-
-	case StateDigitSlashWSColonColon: // starts digit then slash 02/ more digits/slashes then whitespace double colons
-		// 2014/07/10 06:55:38.156283
 		// 03/19/2012 10:11:59
-		// 3/1/2012 10:11:59
-		// 03/1/2012 10:11:59
-		// 3/01/2012 10:11:59
-		if firstSlash == 4 {
-			for _, layout := range []string{"2006/01/02 15:04:05", "2006/1/02 15:04:05", "2006/01/2 15:04:05", "2006/1/2 15:04:05"} {
-				if t, err := parse(layout, datestr, loc); err == nil {
-					return t, StateDigitSlashWSColonColon, nil
-				}
-			}
-		} else {
-			for _, layout := range []string{"01/02/2006 15:04:05", "1/02/2006 15:04:05", "01/2/2006 15:04:05", "1/2/2006 15:04:05"} {
-				if t, err := parse(layout, datestr, loc); err == nil {
-					return t, StateDigitSlashWSColonColon, nil
-				}
-			}
-		}
-
-	case StateDigitSlashWSColonColonAMPM: // starts digit then slash 02/ more digits/slashes then whitespace double colons
 		// 2014/07/10 06:55:38.156283 PM
-		// 03/19/2012 10:11:59 PM
-		// 3/1/2012 10:11:59 PM
-		// 03/1/2012 10:11:59 PM
-		// 3/01/2012 10:11:59 PM
-
+		layouts := slashLayouts[state].monthFirst
 		if firstSlash == 4 {
-			for _, layout := range []string{"2006/01/02 03:04:05 PM", "2006/1/02 03:04:05 PM", "2006/01/2 03:04:05 PM", "2006/1/2 03:04:05 PM",
-				"2006/01/02 3:04:05 PM", "2006/1/02 3:04:05 PM", "2006/01/2 3:04:05 PM", "2006/1/2 3:04:05 PM"} {
-				if t, err := parse(layout, datestr, loc); err == nil {
-					return t, StateDigitSlashWSColonColonAMPM, nil
-				}
-			}
-		} else {
-			for _, layout := range []string{"01/02/2006 03:04:05 PM", "1/02/2006 03:04:05 PM", "01/2/2006 03:04:05 PM", "1/2/2006 03:04:05 PM"} {
-				if t, err := parse(layout, datestr, loc); err == nil {
-					return t, StateDigitSlashWSColonColonAMPM, nil
-				}
+			layouts = slashLayouts[state].yearFirst
+		}
+		for _, layout := range layouts {
+			if t, err := p.parse(layout, datestr); err == nil {
+				return t, state, nil
 			}
 		}
 
+	case StateWeekdayComma:
+		// stopped on the dash of the date
+		// Monday, 02-Jan-06 15:04:05 MST
+		if stopped {
+			t, err := p.parse("Monday, 02-Jan-06 15:04:05 MST", datestr)
+			return t, StateWeekdayComma, err
+		}
 	case StateWeekdayCommaOffset:
 		// Monday, 02 Jan 2006 15:04:05 -0700
 		// Monday, 02 Jan 2006 15:04:05 +0100
-		t, err := parse("Monday, 02 Jan 2006 15:04:05 -0700", datestr, loc)
+		t, err := p.parse("Monday, 02 Jan 2006 15:04:05 -0700", datestr)
 		return t, StateWeekdayCommaOffset, err
 	case StateWeekdayAbbrevComma: // Starts alpha then comma
-		// Mon, 02-Jan-06 15:04:05 MST
+		// Mon, 02-Jan-06 15:04:05 MST, stopped on the dash of the date
 		// Mon, 02 Jan 2006 15:04:05 MST
-		t, err := parse("Mon, 02 Jan 2006 15:04:05 MST", datestr, loc)
+		layout := "Mon, 02 Jan 2006 15:04:05 MST"
+		if stopped {
+			layout = "Mon, 02-Jan-06 15:04:05 MST"
+		}
+		t, err := p.parse(layout, datestr)
 		return t, StateWeekdayAbbrevComma, err
 	case StateWeekdayAbbrevCommaOffset:
 		// Mon, 02 Jan 2006 15:04:05 -0700
 		// Thu, 13 Jul 2017 08:58:40 +0100
 		// RFC1123Z    = "Mon, 02 Jan 2006 15:04:05 -0700" // RFC1123 with numeric zone
-		t, err := parse("Mon, 02 Jan 2006 15:04:05 -0700", datestr, loc)
+		t, err := p.parse("Mon, 02 Jan 2006 15:04:05 -0700", datestr)
 		return t, StateWeekdayAbbrevCommaOffset, err
 	case StateWeekdayAbbrevCommaOffsetZone:
 		// Tue, 11 Jul 2017 16:28:13 +0200 (CEST)
-		t, err := parse("Mon, 02 Jan 2006 15:04:05 -0700 (CEST)", datestr, loc)
+		i := strings.LastIndex(datestr, " (")
+		if i < 0 || !isZoneComment(datestr[i:]) {
+			break
+		}
+		t, err := p.parse("Mon, 02 Jan 2006 15:04:05 -0700", datestr[:i])
+		if err == nil {
+			p.ignore(datestr[i:])
+		}
 		return t, StateWeekdayAbbrevCommaOffsetZone, err
 	case StateHowLongAgo:
 		// 1 minutes ago
@@ -942,13 +727,13 @@ iterRunes:
 		// 1 days ago
 		switch {
 		case strings.Contains(datestr, "minutes ago"):
-			t, err := agoTime(datestr, time.Minute)
+			t, err := p.agoTime(datestr, "minutes ago", time.Minute)
 			return t, StateHowLongAgo, err
 		case strings.Contains(datestr, "hours ago"):
-			t, err := agoTime(datestr, time.Hour)
+			t, err := p.agoTime(datestr, "hours ago", time.Hour)
 			return t, StateHowLongAgo, err
 		case strings.Contains(datestr, "days ago"):
-			t, err := agoTime(datestr, Day)
+			t, err := p.agoTime(datestr, "days ago", Day)
 			return t, StateHowLongAgo, err
 		}
 	}
@@ -956,11 +741,91 @@ iterRunes:
 	return time.Time{}, StateStart, fmt.Errorf("Could not find date format for %s", datestr)
 }
 
-func agoTime(datestr string, d time.Duration) (time.Time, error) {
+// ignore records text the lenient fallbacks left out of the parse.
+func (p *parser) ignore(s string) {
+	p.ignored = strings.TrimSpace(s)
+}
+
+// parseZoneThenText parses a "2006-01-02 15:04:05" date ending at end
+// followed by a zone abbreviation from the table or an IANA zone name, and
+// then other text which is ignored:
+//
+//     2014-12-16 06:20:00 EST and something else
+//     2014-12-16 06:20:00 America/New_York and something else
+//
+// zoned is false when the first word after the date is not a zone.
+func (p *parser) parseZoneThenText(datestr string, end int) (t time.Time, zoned bool, err error) {
+	rest := strings.TrimLeft(datestr[end:], " \t")
+	word := rest
+	if i := strings.IndexAny(rest, " \t"); i >= 0 {
+		word = rest[:i]
+	}
+	zend := len(datestr) - len(rest) + len(word)
+	if _, ok := p.zoneAbbrevs[word]; ok {
+		t, err = p.parse("2006-01-02 15:04:05 MST", datestr[:zend])
+	} else if zone, ok := zoneName(word); ok {
+		loc := p.loc
+		p.loc = zone
+		t, err = p.parse("2006-01-02 15:04:05", datestr[:end])
+		p.loc = loc
+	} else {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		// dropping the zone as well would give the wrong instant
+		return time.Time{}, true, err
+	}
+	p.ignore(datestr[zend:])
+	return t, true, nil
+}
+
+// looksLikeOffset reports whether s, the text after the time of a date,
+// starts with an offset such as "+0300", "GMT-7" or "UTC+25", in range or
+// not.  Other words are not taken for a zone unless they are in the table
+// of zone abbreviations or an IANA name, see parseZoneThenText.
+func looksLikeOffset(s string) bool {
+	s = strings.TrimLeft(s, " \t")
+	for _, name := range []string{"GMT", "UTC", "UT"} {
+		if strings.HasPrefix(s, name) {
+			s = s[len(name):]
+			break
+		}
+	}
+	return len(s) > 1 && (s[0] == '+' || s[0] == '-') && isDigit(s[1])
+}
+
+// isZoneComment reports whether s is a parenthesized zone description
+// trailing a date, such as " (GMT Daylight Time)".
+func isZoneComment(s string) bool {
+	return strings.HasPrefix(s, " (") && strings.HasSuffix(s, ")")
+}
+
+func (p *parser) agoTime(datestr, unit string, d time.Duration) (time.Time, error) {
 	dstrs := strings.Split(datestr, " ")
 	m, err := strconv.Atoi(dstrs[0])
 	if err != nil {
 		return time.Time{}, err
 	}
-	return time.Now().Add(-d * time.Duration(m)), nil
+	if !strings.HasPrefix(datestr, dstrs[0]+" "+unit) {
+		return time.Time{}, fmt.Errorf("Could not find date format for %s", datestr)
+	}
+	if rest := datestr[len(dstrs[0])+1+len(unit):]; rest != "" {
+		if r, _ := utf8.DecodeRuneInString(rest); unicode.IsLetter(r) || unicode.IsDigit(r) {
+			// 3 days agoxyz
+			return time.Time{}, fmt.Errorf("Could not find date format for %s", datestr)
+		}
+		if p.strict {
+			return time.Time{}, fmt.Errorf("Could not find date format for %s", datestr)
+		}
+		p.ignore(rest)
+	}
+	switch d {
+	case time.Minute:
+		p.precision = PrecisionMinute
+	case time.Hour:
+		p.precision = PrecisionHour
+	default:
+		p.precision = PrecisionDay
+	}
+	return p.now().Add(-d * time.Duration(m)), nil
 }
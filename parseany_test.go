@@ -3,6 +3,7 @@ package dateparse
 import (
 	"fmt"
 	"testing"
+	"time"
 )
 
 func TestParseAny(t *testing.T) {
@@ -12,3 +13,36 @@ func TestParseAny(t *testing.T) {
 	fmt.Println(ParseLocal("1 hours ago"))
 	fmt.Println(ParseLocal("1 minutes ago"))
 }
+
+func TestParseLogTimestamps(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC)
+	p, err := NewParser(ReferenceClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in    string
+		out   string
+		state DateState
+	}{
+		{"Dec 31 23:59:59", "2019-12-31 23:59:59 +0000 UTC", StateAlphaWSDigitColon},
+		{"Jan  1 00:29:00", "2020-01-01 00:29:00 +0000 UTC", StateAlphaWSDigitColon},
+		{"Jan 01 08:00:00.123", "2020-01-01 08:00:00.123 +0000 UTC", StateAlphaWSDigitColon},
+		{"Feb 29 10:00:00", "2016-02-29 10:00:00 +0000 UTC", StateAlphaWSDigitColon},
+		{"May 18, 2009", "2009-05-18 00:00:00 +0000 UTC", StateAlphaWSDigitComma},
+		{"02/Jan/2006:15:04:05 -0700", "2006-01-02 15:04:05 -0700 -0700", StateDigitSlashAlpha},
+		{"2006-01-02T15:04:05.000+08:00", "2006-01-02 15:04:05 +0800 +0800", StateDigitDashTOffsetColon},
+		{"Mon 2006-01-02 15:04:05 UTC", "2006-01-02 15:04:05 +0000 UTC", StateAlphaWSDigitDash},
+		{"1332151919.25", "2012-03-19 10:11:59.25 +0000 UTC", StateTimestamp},
+	}
+	for _, tc := range tests {
+		res, err := p.ParseIn(tc.in, time.UTC)
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+		if got := res.Time.String(); got != tc.out || res.State != tc.state {
+			t.Errorf("%q: got %s (state %d) want %s (state %d)", tc.in, got, res.State, tc.out, tc.state)
+		}
+	}
+}
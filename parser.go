@@ -0,0 +1,372 @@
+package dateparse
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Parser parses date strings with a fixed set of options.  The package level
+// ParseAny, ParseIn and ParseLocal use a Parser with the default options.
+// A Parser is safe for concurrent use once it has been created.
+//
+//     p, err := dateparse.NewParser(dateparse.ReferenceClock(clock.Now))
+//     res, err := p.ParseAny("Jan  2 15:04:05")
+//
+type Parser struct {
+	now           func() time.Time
+	timeOnly      TimeOnlyPolicy
+	fill          FillMode
+	zoneAbbrevs   map[string][]ZoneAbbrev
+	zoneAmbiguity ZoneAmbiguity
+	overlap       OverlapPolicy
+	gap           GapPolicy
+	yearPolicy    yearPolicy
+	yearArg       int
+	strict        bool
+	bounds        bounds
+	past, future  time.Duration
+	epochBounds   bounds
+	epochDigits   int
+	custom        []customFormat
+	families      Family
+	cache         *layoutCache
+	traceHook     func(Trace)
+}
+
+// ParserOption configures a Parser, see NewParser.
+type ParserOption func(*Parser) error
+
+// ReferenceClock sets the clock used for anything relative to the current
+// time: "now", "3 days ago" and the year of timestamps that do not carry one
+// such as syslog's "Jan  2 15:04:05".  Defaults to time.Now.
+func ReferenceClock(now func() time.Time) ParserOption {
+	return func(p *Parser) error {
+		if now == nil {
+			now = time.Now
+		}
+		p.now = now
+		return nil
+	}
+}
+
+// Strict makes the parser fail unless the whole date string was read.
+// Without it a few formats are accepted with trailing text which is then
+// reported in ParseResult.Ignored, "2014-12-16 06:20:00 foo bar" is
+// 2014-12-16 06:20:00 with "foo bar" ignored.  Text where a zone or offset
+// would go, as in "2014-12-16 06:20:00 UTC+25", is never ignored.  A zone
+// comment in parentheses, as in "Tue, 11 Jul 2017 16:28:13 +0200 (CEST)",
+// is allowed either way.
+func Strict() ParserOption {
+	return func(p *Parser) error {
+		p.strict = true
+		return nil
+	}
+}
+
+// ParseResult is the outcome of a successful parse.
+type ParseResult struct {
+	// Time is the parsed time.
+	Time time.Time
+	// State is the format type that was detected.
+	State DateState
+	// Layout is the time.Parse layout that produced Time, empty when the
+	// value was not parsed with a layout (unix timestamps, "now", "ago").
+	Layout string
+	// NoDate is set when the input was only a time of day such as "15:04",
+	// see TimeOnly for the date Time is given.
+	NoDate bool
+	// TimeOfDay is the wall clock time read when NoDate is set.
+	TimeOfDay TimeOfDay
+	// Precision is the finest unit given in the input, "Feb 2020" is
+	// PrecisionMonth.  The parts of Time finer than that were filled in
+	// according to FillMissing.
+	Precision Precision
+	// Ignored is the text a lenient parse left out, such as the trailing
+	// comment of "Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)".
+	// See Strict.
+	Ignored string
+	// DST records whether the wall clock time fell in a daylight saving gap
+	// or overlap of the location, see DSTGap and DSTOverlap.
+	DST DSTResolution
+}
+
+var defaultParser = &Parser{now: time.Now, zoneAbbrevs: defaultZoneAbbrevs, families: FamilyAll}
+
+// NewParser creates a Parser with the given options applied on top of the
+// defaults.
+func NewParser(opts ...ParserOption) (*Parser, error) {
+	p := &Parser{now: time.Now, zoneAbbrevs: defaultZoneAbbrevs, families: FamilyAll}
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// ParseAny parse an unknown date format, detect the layout, parse.
+// Equivalent Timezone rules as time.Parse()
+func (p *Parser) ParseAny(datestr string) (ParseResult, error) {
+	return p.parse(datestr, nil)
+}
+
+// ParseIn with Location, equivalent to time.ParseInLocation() timezone/offset
+// rules, see the package level ParseIn.
+func (p *Parser) ParseIn(datestr string, loc *time.Location) (ParseResult, error) {
+	return p.parse(datestr, loc)
+}
+
+// ParseLocal is ParseIn using the global time.Local variable for Location.
+func (p *Parser) ParseLocal(datestr string) (ParseResult, error) {
+	return p.parse(datestr, time.Local)
+}
+
+func (p *Parser) parse(datestr string, loc *time.Location) (ParseResult, error) {
+	if p.traceHook != nil {
+		tr := p.explain(datestr, loc)
+		p.traceHook(tr)
+		return tr.Result, tr.Err
+	}
+	if p.cache != nil {
+		return p.parseCached(datestr, loc)
+	}
+	ps := parser{Parser: p, loc: loc, strict: p.strict}
+	return ps.run(datestr)
+}
+
+// parseFull detects the format of datestr and parses it.
+func (p *Parser) parseFull(datestr string, loc *time.Location) (ParseResult, error) {
+	ps := parser{Parser: p, loc: loc, strict: p.strict}
+	return ps.run(datestr)
+}
+
+// parseLayout parses datestr with a layout found before for a date string
+// in state, and of precision prec when it is known.
+func (p *Parser) parseLayout(datestr string, loc *time.Location, layout string, state DateState, prec Precision) (ParseResult, error) {
+	ps := parser{Parser: p, loc: loc, strict: p.strict}
+	t, err := ps.parse(layout, datestr)
+	if err != nil {
+		return ParseResult{}, err
+	}
+	res := ParseResult{Time: t, State: state, Layout: layout, DST: ps.dst, Precision: prec}
+	err = ps.finish(datestr, &res)
+	return res, err
+}
+
+// parser holds the state of a single parse call.
+type parser struct {
+	*Parser
+	loc *time.Location
+	// strict disables the fallbacks that parse only a leading part of
+	// datestr and ignore the rest.
+	strict bool
+	// prefix is set when datestr is the start of a longer text, for
+	// ParsePrefix, FindAll and the Scanner.  A military zone letter ending
+	// it is then taken for a word of the text.
+	prefix bool
+	// layout is the last layout handed to time.Parse
+	layout string
+	// ignored is the text left out of the parse
+	ignored string
+	noDate  bool
+	tod     TimeOfDay
+	dst     DSTResolution
+	// policyErr is the last time rejected by one of the Parser's
+	// policies, a daylight saving gap or a two digit year, it explains a
+	// failed parse better than "Could not find format".
+	policyErr error
+	// precision is set by the branches that don't use a layout, it is
+	// derived from the layout otherwise.
+	precision Precision
+	// toks are the tokens of the string parseTime scanned
+	toks tokens
+	// fraction is set when the scanned string has a fraction of a second
+	fraction bool
+	// trace records the parse for Explain, nil otherwise
+	trace *Trace
+}
+
+func (p *parser) run(datestr string) (ParseResult, error) {
+	// 2020-02-02 15:04:05 America/New_York
+	rest, zone, hasZone := embeddedZone(datestr)
+	if hasZone {
+		if !strings.HasSuffix(strings.TrimSpace(datestr), "]") {
+			// America/New_York rather than RFC 9557's [Europe/Paris]
+			if err := p.checkZoneFamily(datestr); err != nil {
+				return ParseResult{}, err
+			}
+		}
+		datestr = rest
+		p.loc = zone
+		if strings.HasSuffix(rest, "Z") {
+			// 2020-02-02T14:04:05Z[Europe/Paris] is 15:04:05 in Paris,
+			// some of the layouts read the Z literally
+			p.loc = time.UTC
+		}
+	}
+	var t time.Time
+	var state DateState
+	var err error
+	var offset *time.Location
+	if mayHaveOffset(datestr, !p.prefix) {
+		// 2020-02-02 15:04:05 UTC+8
+		rest, offset, _ = extractOffset(datestr, false, !p.prefix)
+	}
+	if offset != nil {
+		t, state, err = p.parseOffset(datestr, rest, offset)
+	} else {
+		t, state, err = p.parseAny(datestr)
+		if err != nil {
+			// an offset in a place the layouts don't expect
+			if rest, offset, ok := extractOffset(datestr, true, !p.prefix); ok {
+				if ot, ostate, oerr := p.parseOffset(datestr, rest, offset); oerr == nil {
+					t, state, err = ot, ostate, nil
+				}
+			}
+		}
+	}
+	if err != nil && p.policyErr != nil {
+		err = p.policyErr
+	}
+	res := ParseResult{Time: t, State: state, Layout: p.layout, NoDate: p.noDate, TimeOfDay: p.tod, DST: p.dst, Ignored: p.ignored}
+	if err == nil && hasZone {
+		res.Time, err = inZone(t, zone)
+		t = res.Time
+	}
+	if err != nil {
+		return res, err
+	}
+	err = p.finish(datestr, &res)
+	return res, err
+}
+
+// finish fills in the precision of a parsed time, unless res has one, and
+// applies the missing parts and bounds policies.
+func (p *parser) finish(datestr string, res *ParseResult) error {
+	if res.Precision == PrecisionUnknown {
+		p.setPrecision(datestr, res)
+	}
+	res.Time = p.fillMissing(res.Time, res.Precision)
+	return p.checkBounds(datestr, res)
+}
+
+// setPrecision sets the precision of res from the scan or the layout.
+func (p *parser) setPrecision(datestr string, res *ParseResult) {
+	res.Precision = p.precision
+	if res.Precision == PrecisionUnknown {
+		res.Precision = layoutPrecision(p.layout)
+	}
+	if res.Precision == PrecisionSecond {
+		if p.toks.n == 0 {
+			// not scanned, a custom format or a BatchParser layout
+			p.toks.split(datestr)
+			p.fraction = p.toks.fraction(datestr)
+		}
+		if p.fraction {
+			// time.Parse accepts a fraction after the seconds the layout
+			// doesn't mention, even .000
+			res.Precision = PrecisionSubsecond
+		}
+	}
+}
+
+// parseAny is parseTime with the long form and custom formats it doesn't
+// know about.
+func (p *parser) parseAny(datestr string) (time.Time, DateState, error) {
+	custom := p.families&FamilyCustom != 0
+	if custom {
+		if t, ok := p.parseCustom(datestr, BeforeBuiltin); ok {
+			return t, StateCustom, nil
+		}
+	}
+	t, state, err := p.parseTime(datestr)
+	if err != nil && !errors.Is(err, ErrFamilyNotAllowed) {
+		// March 3rd, 2020
+		// Tuesday, March 3rd 2020 at 4:05pm
+		if p.families&FamilyAlpha != 0 {
+			if lt, ok := p.parseLongForm(datestr); ok {
+				return lt, StateLongForm, nil
+			}
+		}
+		if custom {
+			if ct, ok := p.parseCustom(datestr, AfterBuiltin); ok {
+				return ct, StateCustom, nil
+			}
+		}
+	}
+	return t, state, err
+}
+
+func (p *parser) parse(layout, datestr string) (time.Time, error) {
+	t, err := p.parseWith(layout, datestr)
+	if p.trace != nil {
+		p.trace.Attempts = append(p.trace.Attempts, LayoutAttempt{Layout: layout, Err: err})
+	}
+	return t, err
+}
+
+// parseWith is parse without the trace.
+func (p *parser) parseWith(layout, datestr string) (time.Time, error) {
+	p.layout = layout
+	if p.loc == nil || layoutZoned(layout) {
+		t, err := timeParse(layout, datestr, p.loc)
+		if err == nil && layoutZoneOnly(layout) {
+			// EST, CST, ... with no offset to go with them
+			t, err = p.resolveZoneAbbrev(t)
+		}
+		if err == nil {
+			t, err = p.twoDigitYear(layout, t)
+		}
+		return t, err
+	}
+	// read the wall clock time, ParseInLocation would quietly move times
+	// in a daylight saving gap or overlap
+	t, err := timeParse(layout, datestr, nil)
+	if err == nil {
+		t, err = p.twoDigitYear(layout, t)
+	}
+	if err != nil {
+		return t, err
+	}
+	return p.localTime(t, p.loc)
+}
+
+// localTime is wallClock that remembers a rejected gap or overlap.
+func (p *parser) localTime(w time.Time, loc *time.Location) (time.Time, error) {
+	t, err := p.wallClock(w, loc)
+	if err != nil {
+		p.policyErr = err
+	}
+	return t, err
+}
+
+// inferYear fills in the year of a time parsed from a layout without one
+// (time.Parse leaves it at year 0).  It picks the year that puts the
+// timestamp closest to the reference clock without landing more than a day
+// after it, so a "Dec 31 23:59:59" read on the 1st of January is last year.
+func (p *parser) inferYear(t time.Time) (time.Time, error) {
+	now := p.now().In(t.Location())
+	var best time.Time
+	var bestDiff time.Duration
+	// Feb 29 may need to go back several years to find a leap year.
+	for y := now.Year() + 1; y >= now.Year()-8; y-- {
+		c := time.Date(y, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+		if c.Month() != t.Month() || c.Sub(now) > Day {
+			continue
+		}
+		diff := now.Sub(c)
+		if diff < 0 {
+			diff = -diff
+		}
+		if best.IsZero() || diff < bestDiff {
+			best, bestDiff = c, diff
+		}
+	}
+	if best.Location() == time.UTC {
+		return best, nil
+	}
+	// time.Date resolved daylight saving changes its own way
+	w := time.Date(best.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return p.localTime(w, best.Location())
+}
@@ -0,0 +1,89 @@
+package dateparse
+
+import (
+	"fmt"
+	"time"
+)
+
+// Precision is the finest unit of time given in a parsed date string.
+// "Feb 2020" has PrecisionMonth, "2020-02-02 15:04" has PrecisionMinute.
+type Precision int
+
+const (
+	PrecisionUnknown Precision = iota
+	PrecisionYear
+	PrecisionMonth
+	PrecisionDay
+	PrecisionHour
+	PrecisionMinute
+	PrecisionSecond
+	PrecisionSubsecond
+)
+
+// FillMode controls how the parts missing from a date without a time of
+// day are filled in, for partial dates such as "2020", "Feb 2020" or
+// "2020/02" and for plain dates such as "2020-02-02".
+type FillMode int
+
+const (
+	// FillStart uses the start of the period, "Feb 2020" is
+	// 2020-02-01 00:00:00.  This is the default and matches time.Parse.
+	FillStart FillMode = iota
+	// FillEnd uses the last nanosecond of the period, "Feb 2020" is
+	// 2020-02-29 23:59:59.999999999.
+	FillEnd
+	// FillReference takes the missing parts from the reference clock, on
+	// the 15th at 10:30 "Feb 2020" is 2020-02-15 10:30:00.  Days past the
+	// end of the month are clamped to its last day.
+	FillReference
+)
+
+// FillMissing sets how the parts missing from partial dates and dates
+// without a time of day are filled in.  A missing year, as in "Feb 3" or
+// "3 Feb", always comes from the reference clock the same way it does for
+// syslog timestamps.
+func FillMissing(mode FillMode) ParserOption {
+	return func(p *Parser) error {
+		switch mode {
+		case FillStart, FillEnd, FillReference:
+		default:
+			return fmt.Errorf("unknown FillMode %d", mode)
+		}
+		p.fill = mode
+		return nil
+	}
+}
+
+// fillMissing fills in the parts of t finer than prec for dates without a
+// time of day.
+func (p *parser) fillMissing(t time.Time, prec Precision) time.Time {
+	if p.fill == FillStart || prec < PrecisionYear || prec > PrecisionDay {
+		return t
+	}
+	year, month, day := t.Date()
+	if p.fill == FillEnd {
+		switch prec {
+		case PrecisionYear:
+			return time.Date(year+1, 1, 1, 0, 0, 0, -1, t.Location())
+		case PrecisionMonth:
+			return time.Date(year, month+1, 1, 0, 0, 0, -1, t.Location())
+		}
+		return time.Date(year, month, day+1, 0, 0, 0, -1, t.Location())
+	}
+
+	now := p.now().In(t.Location())
+	switch prec {
+	case PrecisionYear:
+		month, day = now.Month(), now.Day()
+	case PrecisionMonth:
+		day = now.Day()
+	}
+	if last := daysIn(year, month); day > last {
+		day = last
+	}
+	return time.Date(year, month, day, now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), t.Location())
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,46 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePartial(t *testing.T) {
+	now := time.Date(2020, 6, 15, 10, 30, 0, 0, time.UTC)
+	clock := ReferenceClock(func() time.Time { return now })
+	tests := []struct {
+		in    string
+		fill  FillMode
+		out   string
+		prec  Precision
+		state DateState
+	}{
+		{"Feb 2020", FillStart, "2020-02-01 00:00:00 +0000 UTC", PrecisionMonth, StateAlphaWSDigit},
+		{"February 2020", FillEnd, "2020-02-29 23:59:59.999999999 +0000 UTC", PrecisionMonth, StateAlphaWSDigit},
+		{"2020/02", FillReference, "2020-02-15 10:30:00 +0000 UTC", PrecisionMonth, StateDigitSlash},
+		{"02/2021", FillReference, "2021-02-15 10:30:00 +0000 UTC", PrecisionMonth, StateDigitSlash},
+		{"Feb 3", FillStart, "2020-02-03 00:00:00 +0000 UTC", PrecisionDay, StateAlphaWSDigit},
+		{"3 Feb", FillEnd, "2020-02-03 23:59:59.999999999 +0000 UTC", PrecisionDay, StateDigitAlpha},
+		{"3 February 2019", FillStart, "2019-02-03 00:00:00 +0000 UTC", PrecisionDay, StateDigitAlpha},
+		{"2014", FillEnd, "2014-12-31 23:59:59.999999999 +0000 UTC", PrecisionYear, StateDigit},
+		{"2014-04", FillStart, "2014-04-01 00:00:00 +0000 UTC", PrecisionMonth, StateDigitDash},
+		{"2014-04-26 17:24:37", FillEnd, "2014-04-26 17:24:37 +0000 UTC", PrecisionSecond, StateDigitDashWs},
+		{"2014-04-26 17:24:37.123", FillStart, "2014-04-26 17:24:37.123 +0000 UTC", PrecisionSubsecond, StateDigitDashWsPeriod},
+		{"2014-04-26 17:24:37.000", FillEnd, "2014-04-26 17:24:37 +0000 UTC", PrecisionSubsecond, StateDigitDashWsPeriod},
+	}
+	for _, tc := range tests {
+		p, err := NewParser(clock, FillMissing(tc.fill))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := p.ParseAny(tc.in)
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+		if got := res.Time.String(); got != tc.out || res.Precision != tc.prec || res.State != tc.state {
+			t.Errorf("%q: got %s (precision %d, state %d) want %s (precision %d, state %d)",
+				tc.in, got, res.Precision, res.State, tc.out, tc.prec, tc.state)
+		}
+	}
+}
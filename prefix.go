@@ -0,0 +1,91 @@
+package dateparse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxPrefixLen bounds how far into a line ParsePrefix looks for the end of
+// the date.  The longest layouts the state machine knows are ~55 bytes:
+//   Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
+const maxPrefixLen = 64
+
+// ParsePrefix parses the date at the start of line, such as the timestamp
+// of a log line, and returns the remainder of the line with the whitespace
+// separating it from the date removed.  The message therefore begins at
+// byte offset len(line)-len(rest).
+//
+//     res, rest, err := dateparse.ParsePrefix("2017-07-19 03:21:51 INFO started")
+//     // res.Time = 2017-07-19 03:21:51 +0000 UTC, rest = "INFO started"
+//
+// The longest prefix ending at a word boundary that parses as a whole is
+// used.  A timestamp wrapped in square brackets, as written by apache, nginx
+// and many logging libraries, is parsed without the brackets.
+func ParsePrefix(line string) (ParseResult, string, error) {
+	return defaultParser.parsePrefix(line, nil)
+}
+
+// ParsePrefix parses the date at the start of line, see the package level
+// ParsePrefix.
+func (p *Parser) ParsePrefix(line string) (ParseResult, string, error) {
+	return p.parsePrefix(line, nil)
+}
+
+// ParsePrefixIn is ParsePrefix with the time.ParseInLocation() rules of
+// ParseIn.
+func (p *Parser) ParsePrefixIn(line string, loc *time.Location) (ParseResult, string, error) {
+	return p.parsePrefix(line, loc)
+}
+
+func (p *Parser) parsePrefix(line string, loc *time.Location) (ParseResult, string, error) {
+	if strings.HasPrefix(line, "[") {
+		// [02/Jan/2006:15:04:05 -0700] "GET / HTTP/1.1"
+		if end := strings.IndexByte(line, ']'); end > 0 && end <= maxPrefixLen {
+			ps := parser{Parser: p, loc: loc, strict: true}
+			if res, err := ps.run(line[1:end]); err == nil {
+				return res, trimSeparator(line[end+1:]), nil
+			}
+		}
+	}
+
+	var buf [maxPrefixLen + 1]int
+	ends := prefixEnds(line, buf[:0])
+	// Longest first so "2017-07-19 03:21:51" wins over "2017-07-19"
+	for i := len(ends) - 1; i >= 0; i-- {
+		ps := parser{Parser: p, loc: loc, strict: true, prefix: true}
+		if res, err := ps.run(line[:ends[i]]); err == nil {
+			return res, trimSeparator(line[ends[i]:]), nil
+		}
+	}
+	if len(line) > maxPrefixLen {
+		line = line[:maxPrefixLen]
+	}
+	return ParseResult{}, "", fmt.Errorf("Could not find date at start of %s", line)
+}
+
+// prefixEnds appends to ends each offset in the first maxPrefixLen bytes of
+// line where a date could end: before a separator or at the end of line.
+func prefixEnds(line string, ends []int) []int {
+	for i := 1; i < len(line) && i <= maxPrefixLen; i++ {
+		if isPrefixSeparator(line[i]) && !isPrefixSeparator(line[i-1]) {
+			ends = append(ends, i)
+		}
+	}
+	if len(line) <= maxPrefixLen {
+		ends = append(ends, len(line))
+	}
+	return ends
+}
+
+func isPrefixSeparator(c byte) bool {
+	switch c {
+	case ' ', '\t', ',', ';', '|':
+		return true
+	}
+	return false
+}
+
+func trimSeparator(s string) string {
+	return strings.TrimLeft(s, " \t")
+}
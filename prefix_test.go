@@ -0,0 +1,34 @@
+package dateparse
+
+import (
+	"testing"
+)
+
+func TestParsePrefix(t *testing.T) {
+	tests := []struct {
+		line string
+		out  string
+		rest string
+	}{
+		{"2017-07-19 03:21:51 INFO started", "2017-07-19 03:21:51 +0000 UTC", "INFO started"},
+		{"2014-12-16 06:20:00 UTC worker exited", "2014-12-16 06:20:00 +0000 UTC", "worker exited"},
+		{"2009-08-12T22:15:09Z\tGET /", "2009-08-12 22:15:09 +0000 UTC", "GET /"},
+		{"[02/Jan/2006:15:04:05 -0700] \"GET / HTTP/1.1\" 200", "2006-01-02 15:04:05 -0700 -0700", "\"GET / HTTP/1.1\" 200"},
+		{"Mon Jan 02 15:04:05 -0700 2006 done", "2006-01-02 15:04:05 -0700 -0700", "done"},
+		{"2014-05-11", "2014-05-11 00:00:00 +0000 UTC", ""},
+	}
+	for _, tc := range tests {
+		res, rest, err := ParsePrefix(tc.line)
+		if err != nil {
+			t.Errorf("%q: %v", tc.line, err)
+			continue
+		}
+		if got := res.Time.String(); got != tc.out || rest != tc.rest {
+			t.Errorf("%q: got %s, %q want %s, %q", tc.line, got, rest, tc.out, tc.rest)
+		}
+	}
+
+	if _, _, err := ParsePrefix("no date here"); err == nil {
+		t.Errorf("expected error for line without a date")
+	}
+}
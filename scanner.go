@@ -0,0 +1,319 @@
+package dateparse
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ErrNoField is returned in a Record whose line has no field to parse, such
+// as a CSV row with fewer columns than ScanDelimited asks for.
+var ErrNoField = errors.New("dateparse: no date field in line")
+
+// scanBatch is the number of lines a Scanner worker parses at once.
+const scanBatch = 256
+
+// Scanner reads lines from an io.Reader and parses a date in each of them
+// with a pool of workers.  The records come out in the order of the lines,
+// with the parse error of the lines that failed.  Like bufio.Scanner it
+// reads until the end of the input, an error or the context is done:
+//
+//     sc, err := dateparse.NewScanner(ctx, f, dateparse.ScanDelimited(",", 0))
+//     for sc.Scan() {
+//         rec := sc.Record()
+//         if rec.Err != nil {
+//             log.Printf("line %d: %v", rec.Line, rec.Err)
+//             continue
+//         }
+//         ...
+//     }
+//     if err := sc.Err(); err != nil {
+//         ...
+//     }
+//
+// A Scanner not read to the end must be closed to stop its workers.
+type Scanner struct {
+	p       *Parser
+	loc     *time.Location
+	workers int
+	field   func(line string) (string, bool)
+	prefix  bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	order  chan *scanJob
+	job    *scanJob
+	i      int
+	rec    Record
+	err    error
+	// readErr is written by the reading goroutine before it closes order
+	readErr error
+}
+
+// Record is a line read by a Scanner.
+type Record struct {
+	// Line is the number of the line, counting from 1.
+	Line int
+	// Text is the line without its line ending.
+	Text string
+	// Field is the part of the line that was parsed.
+	Field string
+	// Result is the parse of Field, valid when Err is nil.
+	Result ParseResult
+	// Err is the parse error, or ErrNoField.
+	Err error
+}
+
+// ScanOption configures a Scanner, see NewScanner.
+type ScanOption func(*Scanner) error
+
+// ScanParser parses with the options of p instead of the defaults.
+func ScanParser(p *Parser) ScanOption {
+	return func(s *Scanner) error {
+		if p == nil {
+			return fmt.Errorf("nil parser")
+		}
+		s.p = p
+		return nil
+	}
+}
+
+// ScanIn parses as ParseIn does in loc, the default is ParseAny.
+func ScanIn(loc *time.Location) ScanOption {
+	return func(s *Scanner) error {
+		s.loc = loc
+		return nil
+	}
+}
+
+// ScanWorkers sets the number of goroutines parsing, the default is
+// GOMAXPROCS.
+func ScanWorkers(n int) ScanOption {
+	return func(s *Scanner) error {
+		if n < 1 {
+			return fmt.Errorf("scanner needs at least 1 worker, got %d", n)
+		}
+		s.workers = n
+		return nil
+	}
+}
+
+// ScanColumn parses the column-th field, counting from 0, of lines split
+// on white space.
+func ScanColumn(column int) ScanOption {
+	return func(s *Scanner) error {
+		if column < 0 {
+			return fmt.Errorf("negative column %d", column)
+		}
+		s.field = func(line string) (string, bool) {
+			fields := strings.Fields(line)
+			if column >= len(fields) {
+				return "", false
+			}
+			return fields[column], true
+		}
+		s.prefix = false
+		return nil
+	}
+}
+
+// ScanDelimited parses the column-th field, counting from 0, of lines split
+// on sep, such as "," for CSV.  Spaces and double quotes around the field
+// are dropped.
+func ScanDelimited(sep string, column int) ScanOption {
+	return func(s *Scanner) error {
+		if sep == "" {
+			return fmt.Errorf("empty delimiter")
+		}
+		if column < 0 {
+			return fmt.Errorf("negative column %d", column)
+		}
+		s.field = func(line string) (string, bool) {
+			fields := strings.SplitN(line, sep, column+2)
+			if column >= len(fields) {
+				return "", false
+			}
+			return strings.Trim(strings.TrimSpace(fields[column]), `"`), true
+		}
+		s.prefix = false
+		return nil
+	}
+}
+
+// ScanPrefix parses the date a line starts with, as in most logs:
+//
+//     2020-06-15 10:30:00 INFO started
+//     Jun 15 10:30:00 host sshd[42]: accepted
+//
+// The date is found as ParsePrefix finds it and Field is its text, without
+// the square brackets of an access log.
+func ScanPrefix() ScanOption {
+	return func(s *Scanner) error {
+		s.field = nil
+		s.prefix = true
+		return nil
+	}
+}
+
+// NewScanner creates a Scanner reading r.  Without ScanColumn,
+// ScanDelimited or ScanPrefix the whole line is parsed.  Cancelling ctx
+// stops the Scanner, Err then returns ctx.Err().
+func NewScanner(ctx context.Context, r io.Reader, opts ...ScanOption) (*Scanner, error) {
+	s := &Scanner{p: defaultParser, workers: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	s.order = make(chan *scanJob, 2*s.workers)
+	work := make(chan *scanJob, 2*s.workers)
+	for i := 0; i < s.workers; i++ {
+		go func() {
+			for job := range work {
+				for i := range job.recs {
+					s.parse(&job.recs[i])
+				}
+				close(job.done)
+			}
+		}()
+	}
+	go s.read(r, work)
+	return s, nil
+}
+
+// scanJob is a batch of lines, done is closed once they are parsed.
+type scanJob struct {
+	recs []Record
+	done chan struct{}
+}
+
+// read splits r into batches of lines and hands them to the workers, and to
+// Scan in the same order.
+func (s *Scanner) read(r io.Reader, work chan<- *scanJob) {
+	defer close(s.order)
+	defer close(work)
+	br := bufio.NewScanner(r)
+	br.Buffer(nil, 1<<20)
+	line := 0
+	for {
+		job := &scanJob{recs: make([]Record, 0, scanBatch), done: make(chan struct{})}
+		for len(job.recs) < scanBatch && br.Scan() {
+			line++
+			job.recs = append(job.recs, Record{Line: line, Text: br.Text()})
+		}
+		if len(job.recs) == 0 {
+			s.readErr = br.Err()
+			return
+		}
+		select {
+		case s.order <- job:
+		case <-s.ctx.Done():
+			return
+		}
+		select {
+		case work <- job:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// parse fills in the field and result of rec.
+func (s *Scanner) parse(rec *Record) {
+	if s.prefix {
+		s.parsePrefix(rec)
+		return
+	}
+	field := rec.Text
+	if s.field != nil {
+		var ok bool
+		if field, ok = s.field(rec.Text); !ok {
+			rec.Err = fmt.Errorf("%w: line %d", ErrNoField, rec.Line)
+			return
+		}
+	}
+	rec.Field = field
+	rec.Result, rec.Err = s.p.parse(rec.Field, s.loc)
+}
+
+// parsePrefix parses the date rec.Text starts with, as ParsePrefix does.
+func (s *Scanner) parsePrefix(rec *Record) {
+	if strings.TrimSpace(rec.Text) == "" {
+		rec.Err = fmt.Errorf("%w: line %d", ErrNoField, rec.Line)
+		return
+	}
+	res, rest, err := s.p.parsePrefix(rec.Text, s.loc)
+	if err != nil {
+		rec.Err = err
+		return
+	}
+	field := strings.TrimRight(rec.Text[:len(rec.Text)-len(rest)], " \t")
+	if strings.HasPrefix(field, "[") && strings.HasSuffix(field, "]") {
+		field = field[1 : len(field)-1]
+	}
+	rec.Field, rec.Result = field, res
+}
+
+// Scan advances to the next record, which Record then returns.  It returns
+// false at the end of the input, on a read error or once the context is
+// done.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+	for s.job == nil || s.i >= len(s.job.recs) {
+		var ok bool
+		select {
+		case s.job, ok = <-s.order:
+		case <-s.ctx.Done():
+			s.err = s.ctx.Err()
+			return false
+		}
+		if !ok {
+			s.err = s.readErr
+			if s.err == nil {
+				s.err = io.EOF
+			}
+			s.cancel()
+			return false
+		}
+		select {
+		case <-s.job.done:
+		case <-s.ctx.Done():
+			s.err = s.ctx.Err()
+			return false
+		}
+		s.i = 0
+	}
+	s.rec = s.job.recs[s.i]
+	s.i++
+	return true
+}
+
+// Record returns the record read by the last call to Scan.
+func (s *Scanner) Record() Record {
+	return s.rec
+}
+
+// Err returns the error that stopped the Scanner, nil at the end of the
+// input.
+func (s *Scanner) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+	return s.err
+}
+
+// Close stops the Scanner and its workers.
+func (s *Scanner) Close() {
+	s.cancel()
+	if s.err == nil {
+		s.err = io.EOF
+	}
+}
@@ -0,0 +1,118 @@
+package dateparse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScanner(t *testing.T) {
+	tests := []struct {
+		opt    ScanOption
+		input  string
+		fields []string
+		errs   []error
+	}{
+		{ScanColumn(1), "a 2020-06-15 x\nb\nc 1/2/2006 y\n", []string{"2020-06-15", "", "1/2/2006"}, []error{nil, ErrNoField, nil}},
+		{ScanDelimited(",", 2), "1,x,\"2020-06-15 10:00:00\"\r\n2,y, nope\n", []string{"2020-06-15 10:00:00", "nope"}, []error{nil, errors.New("")}},
+		{ScanPrefix(), "2020-06-15 10:30:00 INFO started\nJun 15 10:30:00 host sshd[42]: accepted\n\nnot a date\n",
+			[]string{"2020-06-15 10:30:00", "Jun 15 10:30:00", "", ""}, []error{nil, nil, ErrNoField, errors.New("")}},
+	}
+	for _, tc := range tests {
+		sc, err := NewScanner(context.Background(), strings.NewReader(tc.input), tc.opt, ScanWorkers(2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		i := 0
+		for sc.Scan() {
+			rec := sc.Record()
+			if rec.Line != i+1 || rec.Field != tc.fields[i] {
+				t.Errorf("%q: record %d = %+v", tc.input, i, rec)
+			}
+			switch want := tc.errs[i]; {
+			case want == nil && rec.Err != nil:
+				t.Errorf("%q: line %d: %v", tc.input, rec.Line, rec.Err)
+			case want == ErrNoField && !errors.Is(rec.Err, ErrNoField):
+				t.Errorf("%q: line %d: got %v want ErrNoField", tc.input, rec.Line, rec.Err)
+			case want != nil && rec.Err == nil:
+				t.Errorf("%q: line %d: expected error", tc.input, rec.Line)
+			}
+			i++
+		}
+		if err := sc.Err(); err != nil {
+			t.Errorf("%q: %v", tc.input, err)
+		}
+		if i != len(tc.fields) {
+			t.Errorf("%q: got %d records", tc.input, i)
+		}
+	}
+}
+
+func TestScanPrefixMatchesParsePrefix(t *testing.T) {
+	lines := []string{
+		"2014-12-16 06:20:00 UTC worker exited",
+		"[02/Jan/2006:15:04:05 -0700] \"GET / HTTP/1.1\" 200",
+		"2009-08-12T22:15:09Z\tGET /",
+		"Mon Jan 02 15:04:05 -0700 2006 done",
+	}
+	sc, err := NewScanner(context.Background(), strings.NewReader(strings.Join(lines, "\n")), ScanPrefix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; sc.Scan(); i++ {
+		rec := sc.Record()
+		res, rest, err := ParsePrefix(rec.Text)
+		if rec.Err != nil || err != nil || !rec.Result.Time.Equal(res.Time) || !strings.HasSuffix(rec.Text, rest) {
+			t.Errorf("%q: scanned %+v, ParsePrefix %v %q %v", lines[i], rec, res.Time, rest, err)
+		}
+	}
+}
+
+func TestScannerOrder(t *testing.T) {
+	var b strings.Builder
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	const n = 5000
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "%d,%s\n", i, start.Add(time.Duration(i)*time.Minute).Format("2006-01-02 15:04:05"))
+	}
+	sc, err := NewScanner(context.Background(), strings.NewReader(b.String()), ScanDelimited(",", 1), ScanWorkers(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := 0
+	for sc.Scan() {
+		rec := sc.Record()
+		if want := start.Add(time.Duration(i) * time.Minute); rec.Err != nil || !rec.Result.Time.Equal(want) {
+			t.Fatalf("record %d: %+v", i, rec)
+		}
+		i++
+	}
+	if sc.Err() != nil || i != n {
+		t.Fatalf("got %d records, err %v", i, sc.Err())
+	}
+}
+
+func TestScannerCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	input := strings.Repeat("2020-06-15\n", 10000)
+	sc, err := NewScanner(ctx, strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sc.Scan() {
+		t.Fatal("expected a record")
+	}
+	cancel()
+	for sc.Scan() {
+	}
+	if sc.Err() != context.Canceled {
+		t.Errorf("got %v want context.Canceled", sc.Err())
+	}
+
+	if _, err := NewScanner(context.Background(), strings.NewReader(""), ScanWorkers(0)); err == nil {
+		t.Error("expected error for 0 workers")
+	}
+}
@@ -0,0 +1,224 @@
+package dateparse
+
+import (
+	"fmt"
+)
+
+// stateNames are the names of the states without the State prefix.
+var stateNames = [...]string{
+	StateStart:                             "Start",
+	StateDigit:                             "Digit",
+	StateDigitDash:                         "DigitDash",
+	StateDigitDashAlpha:                    "DigitDashAlpha",
+	StateDigitDashWs:                       "DigitDashWs",
+	StateDigitDashWsWs:                     "DigitDashWsWs",
+	StateDigitDashWsWsAMPMMaybe:            "DigitDashWsWsAMPMMaybe",
+	StateDigitDashWsWsOffset:               "DigitDashWsWsOffset",
+	StateDigitDashWsWsOffsetAlpha:          "DigitDashWsWsOffsetAlpha",
+	StateDigitDashWsWsOffsetColonAlpha:     "DigitDashWsWsOffsetColonAlpha",
+	StateDigitDashWsWsOffsetColon:          "DigitDashWsWsOffsetColon",
+	StateDigitDashWsOffset:                 "DigitDashWsOffset",
+	StateDigitDashWsWsAlpha:                "DigitDashWsWsAlpha",
+	StateDigitDashWsPeriod:                 "DigitDashWsPeriod",
+	StateDigitDashWsPeriodAlpha:            "DigitDashWsPeriodAlpha",
+	StateDigitDashWsPeriodOffset:           "DigitDashWsPeriodOffset",
+	StateDigitDashWsPeriodOffsetAlpha:      "DigitDashWsPeriodOffsetAlpha",
+	StateDigitDashT:                        "DigitDashT",
+	StateDigitDashTZ:                       "DigitDashTZ",
+	StateDigitDashTZDigit:                  "DigitDashTZDigit",
+	StateDigitDashTOffset:                  "DigitDashTOffset",
+	StateDigitDashTOffsetColon:             "DigitDashTOffsetColon",
+	StateDigitSlash:                        "DigitSlash",
+	StateDigitSlashWS:                      "DigitSlashWS",
+	StateDigitSlashWSColon:                 "DigitSlashWSColon",
+	StateDigitSlashWSColonAMPM:             "DigitSlashWSColonAMPM",
+	StateDigitSlashWSColonColon:            "DigitSlashWSColonColon",
+	StateDigitSlashWSColonColonAMPM:        "DigitSlashWSColonColonAMPM",
+	StateDigitAlpha:                        "DigitAlpha",
+	StateAlpha:                             "Alpha",
+	StateAlphaWS:                           "AlphaWS",
+	StateAlphaWSDigitComma:                 "AlphaWSDigitComma",
+	StateAlphaWSAlpha:                      "AlphaWSAlpha",
+	StateAlphaWSAlphaColon:                 "AlphaWSAlphaColon",
+	StateAlphaWSAlphaColonOffset:           "AlphaWSAlphaColonOffset",
+	StateAlphaWSAlphaColonAlpha:            "AlphaWSAlphaColonAlpha",
+	StateAlphaWSAlphaColonAlphaOffset:      "AlphaWSAlphaColonAlphaOffset",
+	StateAlphaWSAlphaColonAlphaOffsetAlpha: "AlphaWSAlphaColonAlphaOffsetAlpha",
+	StateWeekdayComma:                      "WeekdayComma",
+	StateWeekdayCommaOffset:                "WeekdayCommaOffset",
+	StateWeekdayAbbrevComma:                "WeekdayAbbrevComma",
+	StateWeekdayAbbrevCommaOffset:          "WeekdayAbbrevCommaOffset",
+	StateWeekdayAbbrevCommaOffsetZone:      "WeekdayAbbrevCommaOffsetZone",
+	StateHowLongAgo:                        "HowLongAgo",
+	StateTimestamp:                         "Timestamp",
+	StateNow:                               "Now",
+	StateAlphaWSDigit:                      "AlphaWSDigit",
+	StateAlphaWSDigitColon:                 "AlphaWSDigitColon",
+	StateAlphaWSDigitDash:                  "AlphaWSDigitDash",
+	StateDigitSlashAlpha:                   "DigitSlashAlpha",
+	StateDigitPeriod:                       "DigitPeriod",
+	StateDigitColon:                        "DigitColon",
+	StateTimeOfDay:                         "TimeOfDay",
+	StateLongForm:                          "LongForm",
+	StateCustom:                            "Custom",
+}
+
+// String returns the name of s without the State prefix, "DigitDash" for
+// StateDigitDash.
+func (s DateState) String() string {
+	if s >= 0 && int(s) < len(stateNames) {
+		return stateNames[s]
+	}
+	return fmt.Sprintf("DateState(%d)", int(s))
+}
+
+// MarshalText encodes s as its name, for JSON and logs.
+func (s DateState) MarshalText() ([]byte, error) {
+	if s < 0 || int(s) >= len(stateNames) {
+		return nil, fmt.Errorf("dateparse: invalid DateState %d", int(s))
+	}
+	return []byte(stateNames[s]), nil
+}
+
+// UnmarshalText decodes a state name written by MarshalText.
+func (s *DateState) UnmarshalText(text []byte) error {
+	for state, name := range stateNames {
+		if name == string(text) {
+			*s = DateState(state)
+			return nil
+		}
+	}
+	return fmt.Errorf("dateparse: unknown DateState %q", text)
+}
+
+// Layouts returns the time.Parse layouts ParseResult.Layout may hold for a
+// date string in s, nil for the states that aren't parsed with a layout
+// such as StateTimestamp and StateHowLongAgo, or whose layouts come from
+// the Parser such as StateCustom.
+func (s DateState) Layouts() []string {
+	return append([]string(nil), stateLayouts[s]...)
+}
+
+// Family returns the format family of the date strings in s, see
+// AllowFamilies.  StateDigit is FamilyISO or FamilyEpoch, StateDigitAlpha
+// FamilyAlpha or FamilyCJK and StateTimeOfDay FamilyKeyword or FamilyCJK,
+// depending on the date string.
+func (s DateState) Family() Family {
+	switch s {
+	case StateDigit:
+		return FamilyISO | FamilyEpoch
+	case StateDigitAlpha:
+		return FamilyAlpha | FamilyCJK
+	case StateTimeOfDay:
+		return FamilyKeyword | FamilyCJK
+	}
+	return stateFamily(s, "")
+}
+
+// Precision returns the precision of the date strings parsed in s, the
+// coarsest one when they differ, as 2006-01 and 2006-01-02 do in
+// StateDigitDash.  ParseResult.Precision is the precision of a single date
+// string.  It is PrecisionUnknown for StateStart, StateLongForm and
+// StateCustom.
+func (s DateState) Precision() Precision {
+	switch s {
+	case StateTimestamp:
+		// 1332151919
+		return PrecisionSecond
+	case StateDigitPeriod, StateNow:
+		return PrecisionSubsecond
+	case StateHowLongAgo:
+		// 3 days ago
+		return PrecisionDay
+	case StateTimeOfDay:
+		// 下午3点
+		return PrecisionHour
+	}
+	prec := PrecisionUnknown
+	for _, layout := range stateLayouts[s] {
+		if lp := layoutPrecision(layout); prec == PrecisionUnknown || lp < prec {
+			prec = lp
+		}
+	}
+	return prec
+}
+
+// stateLayouts are the layouts parseTime uses in each state.
+// TestDateStateLayouts parses a date string in every state listed and
+// checks its layout is here.
+var stateLayouts = func() map[DateState][]string {
+	clocks := clockLayouts()
+	// 12 Feb 2006, 19:17
+	var digitAlpha []string
+	for _, day := range []string{"2", "02"} {
+		for _, month := range []string{"Jan", "January"} {
+			for _, clock := range clocks {
+				digitAlpha = append(digitAlpha, day+" "+month+" 2006, "+clock)
+			}
+		}
+	}
+	// 2006年01月02日 15:04
+	for _, month := range []string{"1", "01"} {
+		for _, day := range []string{"2", "02"} {
+			cjk := "2006年" + month + "月" + day + "日"
+			digitAlpha = append(digitAlpha, cjk)
+			for _, clock := range clocks {
+				digitAlpha = append(digitAlpha, cjk+" "+clock)
+			}
+		}
+	}
+	digitAlpha = append(digitAlpha, dayMonthLayouts...)
+	// May 8, 2009 5:57:51 PM
+	var alphaComma []string
+	for _, month := range []string{"Jan", "January"} {
+		alphaComma = append(alphaComma, month+" 2, 2006")
+		for _, clock := range clocks {
+			alphaComma = append(alphaComma, month+" 2, 2006 "+clock)
+		}
+	}
+	dash, slash := ymdLayouts[0], ymdLayouts[1]
+
+	layouts := map[DateState][]string{
+		StateDigit:                             {"20060102", "2006"},
+		StateDigitColon:                        timeOfDayLayouts,
+		StateDigitDash:                         append([]string{"2006-1", "2006-01"}, dash[:]...),
+		StateDigitAlpha:                        digitAlpha,
+		StateDigitDashAlpha:                    {"2006-Jan-02"},
+		StateDigitDashTOffset:                  {"2006-01-02T15:04:05-0700"},
+		StateDigitDashTOffsetColon:             {"2006-01-02T15:04:05-07:00"},
+		StateDigitDashT:                        {"2006-01-02T15:04:05"},
+		StateDigitDashTZ:                       {"2006-01-02T15:04Z", "2006-01-02T15:04:05Z"},
+		StateDigitDashWs:                       {"2006-01-02 15:04:05"},
+		StateDigitDashWsWsAMPMMaybe:            {"2006-01-02 03:04:05 PM"},
+		StateDigitDashWsWsOffset:               {"2006-01-02 15:04:05 -0700"},
+		StateDigitDashWsWsOffsetColon:          {"2006-01-02 15:04:05 -07:00"},
+		StateDigitDashWsWsOffsetAlpha:          {"2006-01-02 15:04:05 -0700 UTC", "2006-01-02 15:04:05 +0000 GMT", "2006-01-02 15:04:05 -0700 MST"},
+		StateDigitDashWsWsOffsetColonAlpha:     {"2006-01-02 15:04:05 -07:00 UTC"},
+		StateDigitDashWsOffset:                 {"2006-01-02 15:04:05-07:00"},
+		StateDigitDashWsWsAlpha:                {"2006-01-02 15:04:05 UTC", "2006-01-02 15:04:05 GMT", "2006-01-02 15:04:05 MST", "2006-01-02 15:04:05"},
+		StateDigitDashWsPeriod:                 {"2006-01-02 15:04:05"},
+		StateDigitDashWsPeriodAlpha:            {"2006-01-02 15:04:05 UTC", "2006-01-02 15:04:05 MST"},
+		StateDigitDashWsPeriodOffset:           {"2006-01-02 15:04:05 -0700"},
+		StateDigitDashWsPeriodOffsetAlpha:      {"2006-01-02 15:04:05 -0700 UTC"},
+		StateAlphaWSDigit:                      monthYearLayouts,
+		StateAlphaWSDigitComma:                 alphaComma,
+		StateAlphaWSDigitColon:                 {"Jan _2 15:04:05"},
+		StateAlphaWSDigitDash:                  {"Mon 2006-01-02 15:04:05 MST"},
+		StateAlphaWSAlphaColon:                 {"Mon Jan _2 15:04:05 2006"},
+		StateAlphaWSAlphaColonOffset:           {"Mon Jan 02 15:04:05 -0700 2006"},
+		StateAlphaWSAlphaColonAlpha:            {"Mon Jan _2 15:04:05 MST 2006"},
+		StateAlphaWSAlphaColonAlphaOffset:      {"Mon Jan 02 15:04:05 MST-0700 2006"},
+		StateAlphaWSAlphaColonAlphaOffsetAlpha: {"Mon Jan 02 2006 15:04:05 MST-0700"},
+		StateDigitSlash:                        append(append([]string{"1/2006", "2006/1"}, slash[:]...), shortDates...),
+		StateDigitSlashAlpha:                   commonLogLayouts,
+		StateWeekdayComma:                      {"Monday, 02-Jan-06 15:04:05 MST"},
+		StateWeekdayCommaOffset:                {"Monday, 02 Jan 2006 15:04:05 -0700"},
+		StateWeekdayAbbrevComma:                {"Mon, 02 Jan 2006 15:04:05 MST", "Mon, 02-Jan-06 15:04:05 MST"},
+		StateWeekdayAbbrevCommaOffset:          {"Mon, 02 Jan 2006 15:04:05 -0700"},
+		StateWeekdayAbbrevCommaOffsetZone:      {"Mon, 02 Jan 2006 15:04:05 -0700"},
+	}
+	for state, l := range slashLayouts {
+		layouts[state] = append(append([]string(nil), l.monthFirst...), l.yearFirst...)
+	}
+	return layouts
+}()
@@ -0,0 +1,123 @@
+package dateparse
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDateStateText(t *testing.T) {
+	if got := StateDigitDash.String(); got != "DigitDash" {
+		t.Errorf("got %q", got)
+	}
+	if got := DateState(-1).String(); got != "DateState(-1)" {
+		t.Errorf("got %q", got)
+	}
+	for state := StateStart; state <= StateCustom; state++ {
+		text, err := state.MarshalText()
+		if err != nil {
+			t.Fatalf("%d: %v", state, err)
+		}
+		var back DateState
+		if err := back.UnmarshalText(text); err != nil || back != state {
+			t.Errorf("%s: got %d %v", text, back, err)
+		}
+	}
+
+	b, err := json.Marshal(ParseResult{State: StateWeekdayAbbrevComma})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res ParseResult
+	if err := json.Unmarshal(b, &res); err != nil || res.State != StateWeekdayAbbrevComma {
+		t.Errorf("got %v %v from %s", res.State, err, b)
+	}
+
+	if _, err := DateState(1000).MarshalText(); err == nil {
+		t.Error("expected error for an invalid state")
+	}
+	var s DateState
+	if err := s.UnmarshalText([]byte("StateDigit")); err == nil {
+		t.Error("expected error for an unknown name")
+	}
+}
+
+func TestDateStateLayouts(t *testing.T) {
+	dates := append(append([]string{}, testDates...), isoDates...)
+	dates = append(dates, "2014-04-26 05:24:37 PM", "Monday, 02-Jan-06 15:04:05 MST", "Mon, 02-Jan-06 15:04:05 MST",
+		"12 Feb 2006, 19:17", "2006年1月2日 15:04", "September 17, 2012 10:09 pm", "2014-05-11 08:20:13,787", "15:04",
+		"Mon Jan  2 15:04:05 2006", "Mon Jan  2 15:04:05 MST 2006", "Mon Jan 02 15:04:05 -0700 2006",
+		"Tue, 11 Jul 2017 16:28:13 +0200 (CEST)", "Mon Aug 10 15:44:11 UTC+0100 2015", "Monday, 02 Jan 2006 15:04:05 -0700",
+		"Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)", "2013-Feb-03", "3/31/2014", "08/21/71", "2014/3/31",
+		"4/8/2014 22:05", "8/8/1965 01:00 PM", "03/19/2012 10:11:59", "2014/07/10 06:55:38.156283 PM",
+		"2006-01-02T15:04:05+0000", "2014-12-16 06:20:00 UTC", "2014-04-26 13:13:44 +09:00", "2014-04",
+		"2015-02-18 00:12:00 +0000 GMT", "2016-03-14 00:00:00.000 +0000 UTC", "2014-12-16 06:20:00.000 UTC",
+		"20140601", "Feb 2020", "3 February", "02/Jan/2006:15:04:05 -0700", "Jan 02 15:04:05.000", "Mon 2006-01-02 15:04:05 MST",
+		"2015-02-18 00:12:00 +00:00 UTC", "2017-07-19 03:21:51+00:00", "2012-08-03 18:31:59.257000000 +0000",
+		"Thu, 13 Jul 2017 08:58:40 +0100")
+	covered := make(map[DateState]bool)
+	for _, datestr := range dates {
+		res, err := defaultParser.ParseAny(datestr)
+		if err != nil || res.Layout == "" || res.State == StateCustom || res.State == StateLongForm {
+			continue
+		}
+		covered[res.State] = true
+		found := false
+		for _, layout := range res.State.Layouts() {
+			found = found || layout == res.Layout
+		}
+		if !found {
+			t.Errorf("%q: layout %q not in the layouts of %s", datestr, res.Layout, res.State)
+		}
+		if f := res.State.Family(); f&stateFamily(res.State, datestr) == 0 {
+			t.Errorf("%q: family %s of %s", datestr, stateFamily(res.State, datestr), f)
+		}
+		if prec := res.State.Precision(); prec > res.Precision {
+			t.Errorf("%q: precision %d finer than %d", datestr, prec, res.Precision)
+		}
+	}
+	// stateLayouts is kept by hand next to parseTime, every state in it
+	// needs an example above
+	for state := StateStart; state <= StateCustom; state++ {
+		if state.Layouts() != nil && !covered[state] {
+			t.Errorf("no date string parsed in %s", state)
+		}
+	}
+	if StateTimestamp.Layouts() != nil || StateCustom.Layouts() != nil {
+		t.Error("expected no layouts")
+	}
+	// the result is a copy
+	StateDigit.Layouts()[0] = "x"
+	if StateDigit.Layouts()[0] != "20060102" {
+		t.Error("layouts changed")
+	}
+}
+
+func TestDateStateMetadata(t *testing.T) {
+	tests := []struct {
+		state  DateState
+		family Family
+		prec   Precision
+	}{
+		{StateDigit, FamilyISO | FamilyEpoch, PrecisionYear},
+		{StateDigitDash, FamilyISO, PrecisionMonth},
+		{StateDigitDashTZ, FamilyISO, PrecisionMinute},
+		{StateDigitSlash, FamilySlash, PrecisionMonth},
+		{StateDigitSlashWSColonColon, FamilySlash, PrecisionSecond},
+		{StateWeekdayAbbrevComma, FamilyRFC1123, PrecisionSecond},
+		{StateAlphaWSDigit, FamilyAlpha, PrecisionMonth},
+		{StateDigitAlpha, FamilyAlpha | FamilyCJK, PrecisionDay},
+		{StateTimestamp, FamilyEpoch, PrecisionSecond},
+		{StateHowLongAgo, FamilyRelative, PrecisionDay},
+		{StateTimeOfDay, FamilyKeyword | FamilyCJK, PrecisionHour},
+		{StateCustom, FamilyCustom, PrecisionUnknown},
+		{StateStart, 0, PrecisionUnknown},
+	}
+	for _, tc := range tests {
+		if f := tc.state.Family(); f != tc.family {
+			t.Errorf("%s: got family %s want %s", tc.state, f, tc.family)
+		}
+		if prec := tc.state.Precision(); prec != tc.prec {
+			t.Errorf("%s: got precision %d want %d", tc.state, prec, tc.prec)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package dateparse
+
+import (
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	strict, err := NewParser(Strict())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in      string
+		out     string
+		ignored string
+		strict  bool // also parses in strict mode
+	}{
+		{"2014-12-16 06:20:00 UTC", "2014-12-16 06:20:00 +0000 UTC", "", true},
+		{"2014-12-16 16:20:00 foo bar", "2014-12-16 16:20:00 +0000 UTC", "foo bar", false},
+		{"Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)", "2015-07-03 18:04:07 +0100 GMT", "(GMT Daylight Time)", true},
+		{"Fri Jul 03 2015 18:04:07 GMT+0100 and more", "2015-07-03 18:04:07 +0100 GMT", "and more", false},
+		{"Tue, 11 Jul 2017 16:28:13 +0200 (CEST)", "2017-07-11 16:28:13 +0200 +0200", "(CEST)", true},
+		{"Tue, 11 Jul 2017 16:28:13 -0700 (PDT)", "2017-07-11 16:28:13 -0700 -0700", "(PDT)", true},
+		{"2014-12-16 06:20:00 EST junk", "2014-12-16 06:20:00 -0500 EST", "junk", false},
+		{"2014-12-16 06:20:00 PST (Pacific)", "2014-12-16 06:20:00 -0800 PST", "(Pacific)", false},
+		{"2014-12-16 06:20:00 CET INFO started", "2014-12-16 06:20:00 +0100 CET", "INFO started", false},
+		{"2014-12-16 06:20:00 America/New_York junk", "2014-12-16 06:20:00 -0500 EST", "junk", false},
+		{"2014-12-16 06:20:00 foo", "2014-12-16 06:20:00 +0000 UTC", "foo", false},
+		{"2014-12-16 06:20:00 Not/AZone", "2014-12-16 06:20:00 +0000 UTC", "Not/AZone", false},
+		{"2014-05-11 08:20:13,787", "2014-05-11 08:20:13.787 +0000 UTC", "", true},
+	}
+	for _, tc := range tests {
+		res, err := defaultParser.ParseAny(tc.in)
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+		if got := res.Time.String(); got != tc.out || res.Ignored != tc.ignored {
+			t.Errorf("%q: got %s ignored %q want %s ignored %q", tc.in, got, res.Ignored, tc.out, tc.ignored)
+		}
+		if _, err := strict.ParseAny(tc.in); (err == nil) != tc.strict {
+			t.Errorf("%q: strict error %v", tc.in, err)
+		}
+	}
+
+	if res, err := defaultParser.ParseAny("3 days ago xyz"); err != nil || res.Ignored != "xyz" {
+		t.Errorf("got %+v, %v want xyz ignored", res, err)
+	}
+
+	// a dropped offset would give the wrong instant, "ago" ends a word
+	for _, in := range []string{"2014-12-16 06:20:00 UTC+25 foo", "2014-12-16 06:20:00 UTC+25", "2014-12-16 06:20:00 +25:00 foo", "3 days agoxyz"} {
+		if res, err := defaultParser.ParseAny(in); err == nil {
+			t.Errorf("%q: expected error, got %v ignored %q", in, res.Time, res.Ignored)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package dateparse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// TimeOfDay is a wall clock time without a date, the result of parsing
+// inputs such as "15:04", "3:04 PM", "noon" or "下午3点".
+type TimeOfDay struct {
+	Hour, Minute, Second, Nanosecond int
+}
+
+// On returns the time of day on the date of d, in d's location.
+func (t TimeOfDay) On(d time.Time) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), t.Hour, t.Minute, t.Second, t.Nanosecond, d.Location())
+}
+
+func (t TimeOfDay) String() string {
+	if t.Nanosecond != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d.%09d", t.Hour, t.Minute, t.Second, t.Nanosecond)
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+}
+
+// TimeOnlyPolicy controls the date ParseResult.Time is given when the input
+// is only a time of day.
+type TimeOnlyPolicy int
+
+const (
+	// TimeOnReferenceDate puts the time of day on the current date of the
+	// reference clock, in the parse location.  This is the default.
+	TimeOnReferenceDate TimeOnlyPolicy = iota
+	// TimeOnZeroDate leaves Time on January 1 of year 0, as time.Parse
+	// does, callers are expected to use ParseResult.TimeOfDay.
+	TimeOnZeroDate
+)
+
+// TimeOnly sets how inputs without a date are turned into a time.Time.
+// Either way ParseResult.NoDate is set and ParseResult.TimeOfDay holds the
+// wall clock time that was read.
+func TimeOnly(policy TimeOnlyPolicy) ParserOption {
+	return func(p *Parser) error {
+		switch policy {
+		case TimeOnReferenceDate, TimeOnZeroDate:
+		default:
+			return fmt.Errorf("unknown TimeOnlyPolicy %d", policy)
+		}
+		p.timeOnly = policy
+		return nil
+	}
+}
+
+// timeOfDay returns the result of a parse that found only a time of day.
+func (p *parser) timeOfDay(tod TimeOfDay, state DateState) (time.Time, DateState, error) {
+	p.noDate = true
+	p.tod = tod
+	loc := p.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	if p.timeOnly == TimeOnZeroDate {
+		return tod.On(time.Date(0, 1, 1, 0, 0, 0, 0, loc)), state, nil
+	}
+	now := p.now().In(loc)
+	w := tod.On(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))
+	t, err := p.localTime(w, loc)
+	return t, state, err
+}
+
+// chineseDayPeriods are the words that may precede a chinese time of day,
+// and whether they mean the afternoon/evening.
+var chineseDayPeriods = []struct {
+	word string
+	pm   bool
+}{
+	{"凌晨", false},
+	{"早上", false},
+	{"上午", false},
+	{"中午", true},
+	{"下午", true},
+	{"晚上", true},
+}
+
+// mayBeChineseTimeOfDay reports whether datestr starts like a chinese time
+// of day, with a non-ASCII byte first or after one or two digits.
+func mayBeChineseTimeOfDay(datestr string) bool {
+	i := 0
+	for i < len(datestr) && i < 2 && isDigit(datestr[i]) {
+		i++
+	}
+	return i < len(datestr) && datestr[i] >= utf8.RuneSelf
+}
+
+// parseChineseTimeOfDay parses times such as "下午3点", "上午10点30分",
+// "晚上8点半" and "15点04分05秒".
+func parseChineseTimeOfDay(datestr string) (TimeOfDay, Precision, bool) {
+	var tod TimeOfDay
+	prec := PrecisionHour
+	if !mayBeChineseTimeOfDay(datestr) {
+		// every ASCII date string goes through here
+		return tod, prec, false
+	}
+	s := datestr
+	pm, period := false, false
+	for _, dp := range chineseDayPeriods {
+		if strings.HasPrefix(s, dp.word) {
+			s = s[len(dp.word):]
+			pm, period = dp.pm, true
+			break
+		}
+	}
+
+	h, s, ok := chineseTimeField(s, "点", "时")
+	if !ok || h > 23 {
+		return tod, prec, false
+	}
+	tod.Hour = h
+	if strings.HasPrefix(s, "半") {
+		tod.Minute = 30
+		prec = PrecisionMinute
+		s = s[len("半"):]
+	} else if m, rest, ok := chineseTimeField(s, "分"); ok && m < 60 {
+		tod.Minute = m
+		prec = PrecisionMinute
+		s = rest
+		if sec, rest, ok := chineseTimeField(s, "秒"); ok && sec < 60 {
+			tod.Second = sec
+			prec = PrecisionSecond
+			s = rest
+		}
+	}
+	if s != "" {
+		return tod, prec, false
+	}
+
+	if period {
+		if tod.Hour > 12 {
+			// 下午15点 is redundant but unambiguous, 上午15点 is nonsense
+			if !pm {
+				return tod, prec, false
+			}
+		} else if pm && tod.Hour < 12 {
+			tod.Hour += 12
+		} else if !pm && tod.Hour == 12 {
+			// 凌晨12点
+			tod.Hour = 0
+		}
+	}
+	return tod, prec, true
+}
+
+// parseChineseDateTime parses a chinese date followed by a chinese time of
+// day, "2006年1月2日下午3点" or "2006年1月2日 15点30分", which no layout
+// reads.  ok is false when datestr is not one.
+func (p *parser) parseChineseDateTime(datestr string) (t time.Time, ok bool, err error) {
+	i := strings.Index(datestr, "日")
+	if i < 0 || !strings.Contains(datestr[:i], "年") {
+		return time.Time{}, false, nil
+	}
+	i += len("日")
+	tod, prec, ok := parseChineseTimeOfDay(strings.TrimLeft(datestr[i:], " "))
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	if t, err = p.parse(p.toks.chineseDateLayout(), datestr[:i]); err != nil {
+		return time.Time{}, true, err
+	}
+	p.layout = ""
+	p.precision = prec
+	if p.loc == nil {
+		return tod.On(t), true, nil
+	}
+	t, err = p.localTime(tod.On(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)), p.loc)
+	return t, true, err
+}
+
+// chineseTimeField reads one or two digits followed by one of units.
+func chineseTimeField(s string, units ...string) (int, string, bool) {
+	n, i := 0, 0
+	for ; i < len(s) && i < 2 && s[i] >= '0' && s[i] <= '9'; i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	if i == 0 {
+		return 0, s, false
+	}
+	for _, unit := range units {
+		if strings.HasPrefix(s[i:], unit) {
+			return n, s[i+len(unit):], true
+		}
+	}
+	return 0, s, false
+}
@@ -0,0 +1,75 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	now := time.Date(2020, 2, 2, 8, 0, 0, 0, time.UTC)
+	p, err := NewParser(ReferenceClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"15:04", "15:04:00"},
+		{"9:30", "09:30:00"},
+		{"3:04 PM", "15:04:00"},
+		{"12:30am", "00:30:00"},
+		{"15:04:05.123", "15:04:05.123000000"},
+		{"noon", "12:00:00"},
+		{"Midnight", "00:00:00"},
+		{"下午3点", "15:00:00"},
+		{"上午10点30分", "10:30:00"},
+		{"晚上8点半", "20:30:00"},
+		{"凌晨12点", "00:00:00"},
+		{"15点04分05秒", "15:04:05"},
+	}
+	for _, tc := range tests {
+		res, err := p.ParseAny(tc.in)
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+		if !res.NoDate || res.TimeOfDay.String() != tc.out {
+			t.Errorf("%q: got %+v want %s", tc.in, res, tc.out)
+		}
+		if y, m, d := res.Time.Date(); y != 2020 || m != 2 || d != 2 {
+			t.Errorf("%q: expected reference date, got %v", tc.in, res.Time)
+		}
+	}
+
+	// a chinese date followed by a chinese time of day
+	ny, _ := time.LoadLocation("America/New_York")
+	for _, tc := range []struct {
+		in   string
+		loc  *time.Location
+		out  string
+		prec Precision
+	}{
+		{"2020年3月5日下午3点", nil, "2020-03-05 15:00:00 +0000 UTC", PrecisionHour},
+		{"2020年03月05日 上午10点30分", nil, "2020-03-05 10:30:00 +0000 UTC", PrecisionMinute},
+		{"2020年3月5日15点04分05秒", ny, "2020-03-05 15:04:05 -0500 EST", PrecisionSecond},
+	} {
+		res, err := p.ParseIn(tc.in, tc.loc)
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+		if got := res.Time.String(); got != tc.out || res.NoDate || res.Precision != tc.prec {
+			t.Errorf("%q: got %s %+v want %s", tc.in, got, res, tc.out)
+		}
+	}
+
+	p, _ = NewParser(TimeOnly(TimeOnZeroDate))
+	res, err := p.ParseAny("3:04 PM")
+	if err != nil || res.Time.Year() != 0 || res.TimeOfDay.Hour != 15 {
+		t.Errorf("TimeOnZeroDate: got %+v, %v", res, err)
+	}
+	if _, _, err := ParseAny("上午15点"); err == nil {
+		t.Errorf("expected error for 上午15点")
+	}
+}
@@ -0,0 +1,339 @@
+package dateparse
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrAmbiguousZone is returned for a zone abbreviation with several
+// meanings, such as "CST" or "IST", when the parser was created with
+// AmbiguousZones(ZoneAmbiguityReject).
+var ErrAmbiguousZone = errors.New("dateparse: ambiguous time zone abbreviation")
+
+// ZoneAbbrev is one meaning of a time zone abbreviation such as "EST".
+type ZoneAbbrev struct {
+	// Offset is the offset from UTC in seconds.
+	Offset int
+	// Location is an optional IANA zone name.  When set the zone is loaded
+	// with time.LoadLocation and used instead of Offset, so the time
+	// follows its daylight saving rules.
+	Location string
+}
+
+const hour = 60 * 60
+
+// DefaultZoneAbbreviations returns the zone abbreviations used unless the
+// parser was given its own with ZoneAbbreviations.  The map is a fresh
+// copy, callers may extend it.  Abbreviations with more than one meaning
+// list the most common first:
+//
+//     CST  US Central, China, Cuba
+//     IST  India, Ireland, Israel
+//     BST  British Summer, Bangladesh
+//     AST  Atlantic, Arabia
+func DefaultZoneAbbreviations() map[string][]ZoneAbbrev {
+	return map[string][]ZoneAbbrev{
+		"UT":   {{Offset: 0}},
+		"UTC":  {{Offset: 0}},
+		"GMT":  {{Offset: 0}},
+		"Z":    {{Offset: 0}},
+		"WET":  {{Offset: 0}},
+		"WEST": {{Offset: 1 * hour}},
+		"BST":  {{Offset: 1 * hour}, {Offset: 6 * hour}},
+		"IST":  {{Offset: 5*hour + 30*60}, {Offset: 1 * hour}, {Offset: 2 * hour}},
+		"CET":  {{Offset: 1 * hour}},
+		"CEST": {{Offset: 2 * hour}},
+		"MET":  {{Offset: 1 * hour}},
+		"MEST": {{Offset: 2 * hour}},
+		"EET":  {{Offset: 2 * hour}},
+		"EEST": {{Offset: 3 * hour}},
+		"IDT":  {{Offset: 3 * hour}},
+		"MSK":  {{Offset: 3 * hour}},
+		"SAST": {{Offset: 2 * hour}},
+		"CAT":  {{Offset: 2 * hour}},
+		"EAT":  {{Offset: 3 * hour}},
+		"WAT":  {{Offset: 1 * hour}},
+		"PKT":  {{Offset: 5 * hour}},
+		"ICT":  {{Offset: 7 * hour}},
+		"WIB":  {{Offset: 7 * hour}},
+		"WITA": {{Offset: 8 * hour}},
+		"WIT":  {{Offset: 9 * hour}},
+		"HKT":  {{Offset: 8 * hour}},
+		"SGT":  {{Offset: 8 * hour}},
+		"PHT":  {{Offset: 8 * hour}},
+		"AWST": {{Offset: 8 * hour}},
+		"JST":  {{Offset: 9 * hour}},
+		"KST":  {{Offset: 9 * hour}},
+		"ACST": {{Offset: 9*hour + 30*60}},
+		"ACDT": {{Offset: 10*hour + 30*60}},
+		"AEST": {{Offset: 10 * hour}},
+		"AEDT": {{Offset: 11 * hour}},
+		"NZST": {{Offset: 12 * hour}},
+		"NZDT": {{Offset: 13 * hour}},
+		"NST":  {{Offset: -3*hour - 30*60}},
+		"NDT":  {{Offset: -2*hour - 30*60}},
+		"AST":  {{Offset: -4 * hour}, {Offset: 3 * hour}},
+		"ADT":  {{Offset: -3 * hour}},
+		"EST":  {{Offset: -5 * hour}},
+		"EDT":  {{Offset: -4 * hour}},
+		"CST":  {{Offset: -6 * hour}, {Offset: 8 * hour}, {Offset: -5 * hour}},
+		"CDT":  {{Offset: -5 * hour}},
+		"MST":  {{Offset: -7 * hour}},
+		"MDT":  {{Offset: -6 * hour}},
+		"PST":  {{Offset: -8 * hour}},
+		"PDT":  {{Offset: -7 * hour}},
+		"AKST": {{Offset: -9 * hour}},
+		"AKDT": {{Offset: -8 * hour}},
+		"HST":  {{Offset: -10 * hour}},
+		"ART":  {{Offset: -3 * hour}},
+		"BRT":  {{Offset: -3 * hour}},
+		"CLT":  {{Offset: -4 * hour}},
+	}
+}
+
+var defaultZoneAbbrevs = DefaultZoneAbbreviations()
+
+// ZoneAmbiguity is what to do with an abbreviation that has several
+// meanings in the zone abbreviation table.
+type ZoneAmbiguity int
+
+const (
+	// ZoneAmbiguityFirst uses the first meaning listed.  This is the
+	// default.
+	ZoneAmbiguityFirst ZoneAmbiguity = iota
+	// ZoneAmbiguityReject fails the parse with ErrAmbiguousZone.
+	ZoneAmbiguityReject
+)
+
+// ZoneAbbreviations replaces the table used to resolve zone abbreviations,
+// start from DefaultZoneAbbreviations to extend it.
+//
+// An abbreviation is only looked up when the parse location (or time.Local
+// for ParseAny) doesn't know it, time.Parse gets those right by itself.
+// Otherwise time.Parse records a made up zone with a zero offset, which is
+// replaced with the one from the table.  Unknown abbreviations keep the zero
+// offset.
+func ZoneAbbreviations(abbrevs map[string][]ZoneAbbrev) ParserOption {
+	return func(p *Parser) error {
+		for name, zones := range abbrevs {
+			if len(zones) == 0 {
+				return fmt.Errorf("no zones given for abbreviation %q", name)
+			}
+		}
+		p.zoneAbbrevs = abbrevs
+		return nil
+	}
+}
+
+// AmbiguousZones sets what to do with abbreviations such as "CST" that have
+// several meanings.
+func AmbiguousZones(policy ZoneAmbiguity) ParserOption {
+	return func(p *Parser) error {
+		switch policy {
+		case ZoneAmbiguityFirst, ZoneAmbiguityReject:
+		default:
+			return fmt.Errorf("unknown ZoneAmbiguity %d", policy)
+		}
+		p.zoneAmbiguity = policy
+		return nil
+	}
+}
+
+// zoneAbbrev returns the location the abbreviation name stands for, ok is
+// false for abbreviations that are not in the table.
+func (p *parser) zoneAbbrev(name string) (loc *time.Location, ok bool, err error) {
+	zones := p.zoneAbbrevs[name]
+	if len(zones) == 0 {
+		return nil, false, nil
+	}
+	if len(zones) > 1 && p.zoneAmbiguity == ZoneAmbiguityReject {
+		return nil, true, fmt.Errorf("%w %q", ErrAmbiguousZone, name)
+	}
+	z := zones[0]
+	if z.Location != "" {
+		loc, err = loadLocation(z.Location)
+		return loc, true, err
+	}
+	if z.Offset == 0 && (name == "UTC" || name == "Z") {
+		return time.UTC, true, nil
+	}
+	return time.FixedZone(name, z.Offset), true, nil
+}
+
+// resolveZoneAbbrev replaces the zero offset zone time.Parse makes up for
+// an abbreviation it doesn't know with the one from the abbreviation table.
+func (p *parser) resolveZoneAbbrev(t time.Time) (time.Time, error) {
+	name, offset := t.Zone()
+	switch t.Location() {
+	case time.UTC, time.Local, p.loc:
+		// known to time.Parse
+		return t, nil
+	}
+	if offset != 0 {
+		// GMT+1 and friends get a made up offset too, but the right one
+		return t, nil
+	}
+	loc, ok, err := p.zoneAbbrev(name)
+	if !ok || err != nil {
+		return t, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc), nil
+}
+
+// ErrZoneMismatch is returned when a date string names an IANA time zone
+// and also gives an offset that zone doesn't have at that time, as in
+// "2020-02-02T15:04:05+09:00[Europe/Paris]".
+var ErrZoneMismatch = errors.New("dateparse: offset does not match time zone")
+
+// embeddedZone finds an IANA time zone name in datestr and returns datestr
+// without it:
+//
+//     2020-02-02 15:04:05 America/New_York
+//     2020-02-02T15:04:05+01:00[Europe/Paris]
+//     Asia/Shanghai 2020-02-02
+//
+// Bracketed suffixes may be repeated as in RFC 9557, tags such as
+// "[u-ca=gregorian]" are dropped.
+func embeddedZone(datestr string) (string, *time.Location, bool) {
+	if !hasZoneSlash(datestr) {
+		return datestr, nil, false
+	}
+	s := strings.TrimSpace(datestr)
+
+	// 2020-02-02T15:04:05+01:00[Europe/Paris]
+	if strings.HasSuffix(s, "]") {
+		rest := s
+		var loc *time.Location
+		for strings.HasSuffix(rest, "]") {
+			i := strings.LastIndexByte(rest, '[')
+			if i < 0 {
+				return datestr, nil, false
+			}
+			tag := strings.TrimPrefix(rest[i+1:len(rest)-1], "!")
+			rest = rest[:i]
+			if strings.IndexByte(tag, '=') >= 0 {
+				continue
+			}
+			l, ok := zoneName(tag)
+			if !ok || loc != nil {
+				return datestr, nil, false
+			}
+			loc = l
+		}
+		if loc == nil {
+			return datestr, nil, false
+		}
+		return rest, loc, true
+	}
+
+	// 2020-02-02 15:04:05 America/New_York
+	if i := strings.LastIndexAny(s, " \t"); i > 0 {
+		if loc, ok := zoneName(s[i+1:]); ok {
+			return strings.TrimRight(s[:i], " \t"), loc, true
+		}
+	}
+	// Asia/Shanghai 2020-02-02
+	if i := strings.IndexAny(s, " \t"); i > 0 {
+		if loc, ok := zoneName(s[:i]); ok {
+			return strings.TrimLeft(s[i+1:], " \t"), loc, true
+		}
+	}
+	return datestr, nil, false
+}
+
+// zoneName loads name if it looks like an IANA zone name, Area/Location.
+func zoneName(name string) (*time.Location, bool) {
+	if len(name) < 3 || name[0] < 'A' || name[0] > 'Z' || strings.IndexByte(name, '/') < 0 {
+		return nil, false
+	}
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; !isAlnum(c) && c != '/' && c != '_' && c != '-' && c != '+' {
+			return nil, false
+		}
+	}
+	loc, err := loadLocation(name)
+	return loc, err == nil
+}
+
+// hasZoneSlash reports whether s has a slash between two letters, as the
+// zone names have and the dates don't, "2006/01/02" or "02/Jan/2006".
+func hasZoneSlash(s string) bool {
+	for i := 0; i < len(s); i++ {
+		j := strings.IndexByte(s[i:], '/')
+		if j < 0 {
+			return false
+		}
+		i += j
+		if i > 0 && i+1 < len(s) && letterBytes[s[i-1]] && letterBytes[s[i+1]] {
+			return true
+		}
+	}
+	return false
+}
+
+// inZone moves t, parsed from a date string that named zone, into zone.  An
+// explicit offset must be the one zone has at that time, except for UTC
+// ("Z") which RFC 9557 allows with any zone.
+func inZone(t time.Time, zone *time.Location) (time.Time, error) {
+	switch t.Location() {
+	case zone:
+		return t, nil
+	case time.UTC:
+		return t.In(zone), nil
+	}
+	_, offset := t.Zone()
+	zt := t.In(zone)
+	if _, zoffset := zt.Zone(); zoffset != offset {
+		return t, fmt.Errorf("%w: %s is not %s", ErrZoneMismatch, t.Format("-07:00"), zone)
+	}
+	return zt, nil
+}
+
+// locationCache holds the *time.Location of every name loadLocation has
+// loaded, the zoneinfo names are a few hundred.
+var locationCache sync.Map
+
+// maxUnknownZones bounds unknownZones.
+const maxUnknownZones = 1024
+
+// unknownZones holds the error of up to maxUnknownZones names that failed
+// to load.  They come from the input, words of prose such as
+// "Sales/Marketing", and there is no end to them, so when it is full a
+// name is dropped for each one added.
+var unknownZones = struct {
+	sync.Mutex
+	errs map[string]error
+}{errs: make(map[string]error)}
+
+// loadLocation is time.LoadLocation with a cache, loading reads and parses
+// the zoneinfo file every time, or searches every zoneinfo source for a
+// name that fails to load.
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locationCache.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	unknownZones.Lock()
+	err, ok := unknownZones.errs[name]
+	unknownZones.Unlock()
+	if ok {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		unknownZones.Lock()
+		if len(unknownZones.errs) >= maxUnknownZones {
+			for name := range unknownZones.errs {
+				delete(unknownZones.errs, name)
+				break
+			}
+		}
+		unknownZones.errs[name] = err
+		unknownZones.Unlock()
+		return nil, err
+	}
+	locationCache.Store(name, loc)
+	return loc, nil
+}
@@ -0,0 +1,123 @@
+package dateparse
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestZoneAbbreviations(t *testing.T) {
+	now := time.Date(2020, 6, 15, 10, 30, 0, 0, time.UTC)
+	p, err := NewParser(ReferenceClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"2014-12-16 06:20:00 UTC", "2014-12-16 06:20:00 +0000 UTC"},
+		{"2014-12-16 06:20:00 EST", "2014-12-16 06:20:00 -0500 EST"},
+		{"2014-12-16 06:20:00 CST", "2014-12-16 06:20:00 -0600 CST"},
+		{"2016-03-14 00:00:00.000 JST", "2016-03-14 00:00:00 +0900 JST"},
+		{"2015-02-18 00:12:00 -0500 EST", "2015-02-18 00:12:00 -0500 EST"},
+		{"Mon, 02 Jan 2006 15:04:05 PST", "2006-01-02 15:04:05 -0800 PST"},
+		{"Mon Jan  2 15:04:05 IST 2006", "2006-01-02 15:04:05 +0530 IST"},
+		{"Mon 2006-01-02 15:04:05 CEST", "2006-01-02 15:04:05 +0200 CEST"},
+		{"March 3 2020 at 4pm EDT", "2020-03-03 16:00:00 -0400 EDT"},
+		// not in the table
+		{"2014-12-16 06:20:00 XYZ", "2014-12-16 06:20:00 +0000 XYZ"},
+	}
+	for _, tc := range tests {
+		res, err := p.ParseAny(tc.in)
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+		if got := res.Time.String(); got != tc.out {
+			t.Errorf("%q: got %s want %s", tc.in, got, tc.out)
+		}
+	}
+
+	abbrevs := DefaultZoneAbbreviations()
+	abbrevs["CST"] = []ZoneAbbrev{{Offset: 8 * 60 * 60}}
+	abbrevs["XYZ"] = []ZoneAbbrev{{Offset: 3 * 60 * 60}}
+	p, err = NewParser(ZoneAbbreviations(abbrevs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for in, out := range map[string]string{
+		"2014-12-16 06:20:00 CST": "2014-12-16 06:20:00 +0800 CST",
+		"2014-12-16 06:20:00 XYZ": "2014-12-16 06:20:00 +0300 XYZ",
+	} {
+		res, err := p.ParseAny(in)
+		if err != nil {
+			t.Errorf("%q: %v", in, err)
+		} else if got := res.Time.String(); got != out {
+			t.Errorf("%q: got %s want %s", in, got, out)
+		}
+	}
+
+	p, err = NewParser(AmbiguousZones(ZoneAmbiguityReject))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.ParseAny("2014-12-16 06:20:00 CST"); !errors.Is(err, ErrAmbiguousZone) {
+		t.Errorf("CST: got %v want ErrAmbiguousZone", err)
+	}
+	if _, err := p.ParseAny("2014-12-16 06:20:00 EST"); err != nil {
+		t.Errorf("EST: %v", err)
+	}
+}
+
+func TestEmbeddedZoneNames(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"2020-02-02 15:04:05 America/New_York", "2020-02-02 15:04:05 -0500 EST"},
+		{"2020-07-02 15:04:05 America/New_York", "2020-07-02 15:04:05 -0400 EDT"},
+		{"2020-02-02T15:04:05[Europe/Paris]", "2020-02-02 15:04:05 +0100 CET"},
+		{"2020-02-02T15:04:05+01:00[Europe/Paris]", "2020-02-02 15:04:05 +0100 CET"},
+		{"2020-02-02T15:04:05+01:00[Europe/Paris][u-ca=gregorian]", "2020-02-02 15:04:05 +0100 CET"},
+		{"2020-02-02T14:04:05Z[Europe/Paris]", "2020-02-02 15:04:05 +0100 CET"},
+		{"Asia/Shanghai 2020-02-02", "2020-02-02 00:00:00 +0800 CST"},
+		{"02/03/2020 Asia/Tokyo", "2020-02-03 00:00:00 +0900 JST"},
+	}
+	for _, tc := range tests {
+		res, _, err := ParseAny(tc.in)
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+		if got := res.String(); got != tc.out {
+			t.Errorf("%q: got %s want %s", tc.in, got, tc.out)
+		}
+	}
+
+	if _, _, err := ParseAny("2020-02-02T15:04:05+09:00[Europe/Paris]"); !errors.Is(err, ErrZoneMismatch) {
+		t.Errorf("got %v want ErrZoneMismatch", err)
+	}
+	// an unknown zone name is text like any other
+	if res, err := defaultParser.ParseAny("2020-02-02 15:04:05 Not/AZone"); err != nil || res.Ignored != "Not/AZone" {
+		t.Errorf("got %+v, %v want Not/AZone ignored", res, err)
+	}
+	if _, ok := locationCache.Load("Not/AZone"); ok {
+		t.Errorf("unknown zone name was cached as a location")
+	}
+
+	// names that fail to load are remembered, up to maxUnknownZones
+	for i := 0; i < maxUnknownZones+10; i++ {
+		if _, err := loadLocation(fmt.Sprintf("Not/AZone%d", i)); err == nil {
+			t.Fatalf("Not/AZone%d loaded", i)
+		}
+	}
+	unknownZones.Lock()
+	n := len(unknownZones.errs)
+	_, last := unknownZones.errs[fmt.Sprintf("Not/AZone%d", maxUnknownZones+9)]
+	unknownZones.Unlock()
+	if n != maxUnknownZones || !last {
+		t.Errorf("got %d unknown zones, last one cached %v", n, last)
+	}
+}
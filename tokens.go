@@ -0,0 +1,238 @@
+package dateparse
+
+import (
+	"strings"
+)
+
+// tokenKind is the kind of a run of bytes in a date string.
+type tokenKind uint8
+
+const (
+	tokNone   tokenKind = iota
+	tokDigits           // 2006
+	tokAlpha            // Jan, PM, T, 年 (bytes above ASCII count as letters)
+	tokSpace            // one or more spaces or tabs
+	tokPunct            // a single other byte: - / : . , + ( )
+)
+
+// maxTokens is the most tokens recorded, the date strings parseTime knows
+// have far fewer.  Anything beyond is merged into the last token.
+const maxTokens = 32
+
+// token is a run of bytes, start and end are int32 to keep the parser
+// small, which is cleared on every parse.
+type token struct {
+	kind       tokenKind
+	start, end int32
+}
+
+// tokens records the runs of digits, letters, spaces and the separators of
+// the date string parseTime scans, so the final choice of a layout can look
+// at the widths and separators instead of the total length.
+type tokens struct {
+	list [maxTokens]token
+	n    int
+}
+
+// byteKinds is the token kind of every byte.
+var byteKinds = func() (kinds [256]tokenKind) {
+	for c := range kinds {
+		switch {
+		case '0' <= c && c <= '9':
+			kinds[c] = tokDigits
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', c >= 0x80:
+			kinds[c] = tokAlpha
+		case c == ' ' || c == '\t':
+			kinds[c] = tokSpace
+		default:
+			kinds[c] = tokPunct
+		}
+	}
+	return kinds
+}()
+
+// split records the tokens of s.
+func (ts *tokens) split(s string) {
+	n := 0
+	for i := 0; i < len(s); {
+		kind := byteKinds[s[i]]
+		j := i + 1
+		if kind != tokPunct {
+			for j < len(s) && byteKinds[s[j]] == kind {
+				j++
+			}
+		}
+		if n == maxTokens {
+			ts.list[n-1].end = int32(len(s))
+			break
+		}
+		ts.list[n] = token{kind, int32(i), int32(j)}
+		n++
+		i = j
+	}
+	ts.n = n
+}
+
+// count returns the number of c separators.
+func (ts *tokens) count(s string, c byte) int {
+	n := 0
+	for _, t := range ts.list[:ts.n] {
+		if t.kind == tokPunct && s[t.start] == c {
+			n++
+		}
+	}
+	return n
+}
+
+// index returns the position in the list of the first c separator, -1 if
+// there is none.
+func (ts *tokens) index(s string, c byte) int {
+	for k, t := range ts.list[:ts.n] {
+		if t.kind == tokPunct && s[t.start] == c {
+			return k
+		}
+	}
+	return -1
+}
+
+// fraction reports whether the seconds of a time of day in s, digits after
+// a ':', are followed by a fraction: a '.' or ',' and more digits.
+func (ts *tokens) fraction(s string) bool {
+	for k := 3; k < ts.n; k++ {
+		l := ts.list[k-3 : k+1]
+		if l[0].kind == tokPunct && s[l[0].start] == ':' && l[1].kind == tokDigits &&
+			l[2].kind == tokPunct && (s[l[2].start] == '.' || s[l[2].start] == ',') && l[3].kind == tokDigits {
+			return true
+		}
+	}
+	return false
+}
+
+// digits returns the k-th run of digits counting from 0, or a zero token.
+func (ts *tokens) digits(k int) token {
+	for _, t := range ts.list[:ts.n] {
+		if t.kind == tokDigits {
+			if k == 0 {
+				return t
+			}
+			k--
+		}
+	}
+	return token{}
+}
+
+// numLayout returns the layout of a number of t's width, short for one
+// digit and long otherwise, such as "1" or "01".
+func numLayout(t token, short, long string) string {
+	if t.end-t.start == 1 {
+		return short
+	}
+	return long
+}
+
+// ymdLayouts are the layouts of ymdLayout with '-' and '/'.
+var ymdLayouts = [2][4]string{
+	{"2006-01-02", "2006-01-2", "2006-1-02", "2006-1-2"},
+	{"2006/01/02", "2006/01/2", "2006/1/02", "2006/1/2"},
+}
+
+// ymdLayout returns the year first layout with sep for the month and day
+// tokens, "2006-01-02" or "2006-1-2", ...
+func ymdLayout(sep byte, month, day token) string {
+	short := func(t token) int { return btoi(t.end-t.start == 1) }
+	return ymdLayouts[btoi(sep == '/')][2*short(month)+short(day)]
+}
+
+// monthLayout returns "Jan" or "January" for the month name t.
+func monthLayout(t token) string {
+	if t.end-t.start > 3 {
+		return "January"
+	}
+	return "Jan"
+}
+
+// ampmLayout returns the layout of a trailing AM/PM of s, empty without one.
+func (ts *tokens) ampmLayout(s string) string {
+	if ts.n < 2 || ts.list[ts.n-1].kind != tokAlpha {
+		return ""
+	}
+	last := ts.list[ts.n-1]
+	layout := ""
+	switch s[last.start:last.end] {
+	case "AM", "PM":
+		layout = "PM"
+	case "am", "pm":
+		layout = "pm"
+	default:
+		return ""
+	}
+	if ts.list[ts.n-2].kind == tokSpace {
+		layout = " " + layout
+	}
+	return layout
+}
+
+// clockLayout returns the layout of the time of day after the start-th
+// token, "15:04", "15:04:05", "3:04 PM", ... from the number of colons and
+// a trailing AM/PM.  It is empty when there are no colons.
+func (ts *tokens) clockLayout(s string, start int) string {
+	colons := 0
+	for _, t := range ts.list[start:ts.n] {
+		if t.kind == tokPunct && s[t.start] == ':' {
+			colons++
+		}
+	}
+	ampm := ts.ampmLayout(s)
+	hour := "15"
+	if ampm != "" {
+		hour = "3"
+	}
+	switch colons {
+	case 1:
+		return hour + ":04" + ampm
+	case 2:
+		return hour + ":04:05" + ampm
+	}
+	return ""
+}
+
+// clockLayouts are the layouts clockLayout may return.
+func clockLayouts() []string {
+	var clocks []string
+	for _, minSec := range []string{":04", ":04:05"} {
+		clocks = append(clocks, "15"+minSec)
+		for _, ampm := range []string{" PM", "PM", " pm", "pm"} {
+			clocks = append(clocks, "3"+minSec+ampm)
+		}
+	}
+	return clocks
+}
+
+// chineseDateLayout is the layout of a chinese date, 2006年01月02日, read by
+// the first three numbers.
+func (ts *tokens) chineseDateLayout() string {
+	return "2006年" + numLayout(ts.digits(1), "1", "01") + "月" + numLayout(ts.digits(2), "2", "02") + "日"
+}
+
+// digitAlphaLayout returns the layout of the "12 Feb 2006, 19:17" and
+// "2006年01月02日 15:04" dates of StateDigitAlpha, empty for the others.
+func (p *parser) digitAlphaLayout(datestr string) string {
+	ts := &p.toks
+	if k := ts.index(datestr, ','); k >= 0 {
+		// 12 Feb 2006, 19:17
+		clock := ts.clockLayout(datestr, k)
+		if ts.n < 3 || ts.list[1].kind != tokSpace || ts.list[2].kind != tokAlpha || clock == "" {
+			return ""
+		}
+		return numLayout(ts.list[0], "2", "02") + " " + monthLayout(ts.list[2]) + " 2006, " + clock
+	}
+	if strings.Contains(datestr, "年") {
+		// 2006年01月02日 15:04
+		layout := ts.chineseDateLayout()
+		if clock := ts.clockLayout(datestr, 0); clock != "" {
+			layout += " " + clock
+		}
+		return layout
+	}
+	return ""
+}
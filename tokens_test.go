@@ -0,0 +1,61 @@
+package dateparse
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTokenLayouts(t *testing.T) {
+	tests := []struct {
+		in     string
+		out    string
+		layout string
+	}{
+		{"2014-05-11 08:20:13,787", "2014-05-11 08:20:13.787 +0000 UTC", "2006-01-02 15:04:05"},
+		{"2014-05-11 8:20:13,5", "2014-05-11 08:20:13.5 +0000 UTC", "2006-01-02 15:04:05"},
+		{"2014-05-11 08:20:13,787123", "2014-05-11 08:20:13.787123 +0000 UTC", "2006-01-02 15:04:05"},
+		{"2009-08-12T22:15Z", "2009-08-12 22:15:00 +0000 UTC", "2006-01-02T15:04Z"},
+		{"2009-08-12T22:15:09.1Z", "2009-08-12 22:15:09.1 +0000 UTC", "2006-01-02T15:04:05Z"},
+		{"2 Feb 2006, 19:17", "2006-02-02 19:17:00 +0000 UTC", "2 Jan 2006, 15:04"},
+		{"12 February 2006, 19:17:22", "2006-02-12 19:17:22 +0000 UTC", "02 January 2006, 15:04:05"},
+		{"12 Feb 2006, 7:17 pm", "2006-02-12 19:17:00 +0000 UTC", "02 Jan 2006, 3:04 pm"},
+		{"2006年1月2日", "2006-01-02 00:00:00 +0000 UTC", "2006年1月2日"},
+		{"2006年01月02日 15:04:05", "2006-01-02 15:04:05 +0000 UTC", "2006年01月02日 15:04:05"},
+		{"May 8, 2009 17:57:51", "2009-05-08 17:57:51 +0000 UTC", "Jan 2, 2006 15:04:05"},
+		{"September 17, 2012 10:09 pm", "2012-09-17 22:09:00 +0000 UTC", "January 2, 2006 3:04 pm"},
+		{"2006-1-2", "2006-01-02 00:00:00 +0000 UTC", "2006-1-2"},
+		{"2014/1/02", "2014-01-02 00:00:00 +0000 UTC", "2006/1/02"},
+		{"2014-12-16 6:20:00 foo bar", "2014-12-16 06:20:00 +0000 UTC", "2006-01-02 15:04:05"},
+	}
+	for _, tc := range tests {
+		res, err := defaultParser.ParseAny(tc.in)
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+		if got := res.Time.Format("2006-01-02 15:04:05.999999999 -0700 MST"); got != tc.out || res.Layout != tc.layout {
+			t.Errorf("%q: got %s %q want %s %q", tc.in, got, res.Layout, tc.out, tc.layout)
+		}
+	}
+
+	var ts tokens
+	s := "Jan  2 15:04:05.000 +0700"
+	ts.split(s)
+	kinds := []tokenKind{tokAlpha, tokSpace, tokDigits, tokSpace, tokDigits, tokPunct, tokDigits, tokPunct, tokDigits,
+		tokPunct, tokDigits, tokSpace, tokPunct, tokDigits}
+	if ts.n != len(kinds) {
+		t.Fatalf("got %d tokens want %d", ts.n, len(kinds))
+	}
+	for k, kind := range kinds {
+		if ts.list[k].kind != kind {
+			t.Errorf("token %d %q: got kind %d want %d", k, s[ts.list[k].start:ts.list[k].end], ts.list[k].kind, kind)
+		}
+	}
+
+	// tokens beyond maxTokens are merged into the last one
+	s = strings.Repeat("1-", maxTokens)
+	ts.split(s)
+	if last := ts.list[ts.n-1]; ts.n != maxTokens || last.start != maxTokens-1 || int(last.end) != len(s) {
+		t.Errorf("got %d tokens, last %+v", ts.n, last)
+	}
+}
@@ -0,0 +1,376 @@
+package dateparse
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// transition is an edge of the automaton ParseAny runs over the bytes of a
+// date string to find its format.
+type transition struct {
+	// on are the bytes taking the transition, besides the classes below
+	on    string
+	digit bool
+	// letter is any byte unicode.IsLetter accepts
+	letter bool
+	any    bool
+	to     DateState
+	// stop ends the scan after this byte, the state is known
+	stop bool
+	// cond, when set, must also hold
+	cond *guard
+}
+
+// guard is a condition on a transition beyond the byte read.
+type guard struct {
+	name string
+	ok   func(datestr string, i int) bool
+}
+
+func on(bytes string, to DateState) transition { return transition{on: bytes, to: to} }
+func onDigit(to DateState) transition          { return transition{digit: true, to: to} }
+func onLetter(to DateState) transition         { return transition{letter: true, to: to} }
+func onAny(to DateState) transition            { return transition{any: true, to: to} }
+
+// final returns t stopping the scan.
+func (t transition) final() transition {
+	t.stop = true
+	return t
+}
+
+// only returns t taken only when g holds.
+func (t transition) only(g *guard) transition {
+	t.cond = g
+	return t
+}
+
+var (
+	shortHour     = &guard{"i <= 2", func(datestr string, i int) bool { return i <= 2 }}
+	abbrevWeekday = &guard{"i == 3", func(datestr string, i int) bool { return i == 3 }}
+	// the dash of 02-Jan-06 comes before the time, an offset after it
+	dateDash = &guard{"no ':' yet", func(datestr string, i int) bool { return strings.IndexByte(datestr[:i], ':') < 0 }}
+	agoWord  = &guard{`has "ago"`, func(datestr string, i int) bool { return strings.Contains(datestr, "ago") }}
+)
+
+// transitions are the edges out of each state, the first one matching the
+// byte read is taken.  Without a match the state stays, and a state without
+// edges ends the scan.
+var transitions = [...][]transition{
+	StateStart: {
+		onDigit(StateDigit),
+		onLetter(StateAlpha),
+	},
+	// 20140601, 1332151919.123456, 15:04, 3rd of March 2020
+	StateDigit: {
+		onLetter(StateDigitAlpha),
+		on("-", StateDigitDash),
+		on("/", StateDigitSlash),
+		on(".", StateDigitPeriod).final(),
+		on(":", StateDigitColon).final().only(shortHour),
+	},
+	// 2006-01-02, 2006-01-02T15:04:05Z07:00, 2013-04-01 22:43:22, 2013-Feb-03
+	StateDigitDash: {
+		on(" ", StateDigitDashWs),
+		on("T", StateDigitDashT),
+		onLetter(StateDigitDashAlpha).final(),
+	},
+	// 2013-04-01 22:43:22, 2014-05-11 08:20:13,787, 2017-07-19 03:21:51+00:00
+	StateDigitDashWs: {
+		on("-+", StateDigitDashWsOffset),
+		on(".", StateDigitDashWsPeriod),
+		on(" ", StateDigitDashWsWs),
+	},
+	// 2014-04-26 05:24:37 PM, 2014-12-16 06:20:00 UTC, 2006-01-02 15:04:05 -0700
+	StateDigitDashWsWs: {
+		on("AP", StateDigitDashWsWsAMPMMaybe),
+		on("+-", StateDigitDashWsWsOffset),
+		onLetter(StateDigitDashWsWsAlpha).final(),
+	},
+	// 2014-04-26 05:24:37 PM
+	StateDigitDashWsWsAMPMMaybe: {
+		on("M", StateDigitDashWsWsAMPMMaybe).final(),
+		onAny(StateDigitDashWsWsAlpha).final(),
+	},
+	// 2006-01-02 15:04:05 -0700, 2015-02-18 00:12:00 +0000 UTC
+	StateDigitDashWsWsOffset: {
+		on(":", StateDigitDashWsWsOffsetColon),
+		onLetter(StateDigitDashWsWsOffsetAlpha).final(),
+	},
+	// 2006-01-02 15:04:05 -07:00, 2015-02-18 00:12:00 +00:00 UTC
+	StateDigitDashWsWsOffsetColon: {
+		onLetter(StateDigitDashWsWsOffsetColonAlpha).final(),
+	},
+	// 2016-03-14 00:00:00.000, 2014-12-16 06:20:00.000 UTC
+	StateDigitDashWsPeriod: {
+		onLetter(StateDigitDashWsPeriodAlpha).final(),
+		on("+-", StateDigitDashWsPeriodOffset),
+	},
+	// 2016-03-14 00:00:00.000 +0000, 2016-03-14 00:00:00.000 +0000 UTC
+	StateDigitDashWsPeriodOffset: {
+		onLetter(StateDigitDashWsPeriodOffsetAlpha).final(),
+	},
+	// 2006-01-02T15:04:05, 2009-08-12T22:15Z, 2017-06-25T17:46:57+04:00
+	StateDigitDashT: {
+		on("-+", StateDigitDashTOffset),
+		on("Z", StateDigitDashTZ),
+	},
+	// 2006-01-02T15:04:05.999Z, 2006-01-02T15:04:05Z07:00
+	StateDigitDashTZ: {
+		onDigit(StateDigitDashTZDigit),
+	},
+	// 2006-01-02T15:04:05+0000, 2017-06-25T17:46:57.45706582-07:00
+	StateDigitDashTOffset: {
+		on(":", StateDigitDashTOffsetColon),
+	},
+	// 03/19/2012, 2014/07/10 06:55:38.156283, 02/Jan/2006:15:04:05 -0700
+	StateDigitSlash: {
+		on(" ", StateDigitSlashWS),
+		onLetter(StateDigitSlashAlpha).final(),
+	},
+	// 4/8/2014 22:05, 3/1/2012 10:11:59 AM
+	StateDigitSlashWS: {
+		on(":", StateDigitSlashWSColon),
+	},
+	StateDigitSlashWSColon: {
+		on(":", StateDigitSlashWSColonColon),
+		on("AP", StateDigitSlashWSColonAMPM),
+	},
+	StateDigitSlashWSColonColon: {
+		on("AP", StateDigitSlashWSColonColonAMPM),
+	},
+	// 12 Feb 2006, 19:17, 2006年01月02日, 1 days ago
+	StateDigitAlpha: {
+		onAny(StateHowLongAgo).final().only(agoWord),
+		onAny(StateDigitAlpha).final(),
+	},
+	// Mon Jan _2 15:04:05 2006, May 8, 2009 5:57:51 PM, Monday, 02 Jan 2006 15:04:05 -0700
+	StateAlpha: {
+		on(" ", StateAlphaWS),
+		on(",", StateWeekdayAbbrevComma).only(abbrevWeekday),
+		on(",", StateWeekdayComma),
+	},
+	// Monday, 02-Jan-06 15:04:05 MST, Monday, 02 Jan 2006 15:04:05 +0100
+	StateWeekdayComma: {
+		on("-", StateWeekdayComma).final().only(dateDash),
+		on("-+", StateWeekdayCommaOffset),
+	},
+	// Mon, 02-Jan-06 15:04:05 MST, Mon, 02 Jan 2006 15:04:05 -0700
+	StateWeekdayAbbrevComma: {
+		on("-", StateWeekdayAbbrevComma).final().only(dateDash),
+		on("-+", StateWeekdayAbbrevCommaOffset),
+	},
+	// Tue, 11 Jul 2017 16:28:13 +0200 (CEST)
+	StateWeekdayAbbrevCommaOffset: {
+		on("(", StateWeekdayAbbrevCommaOffsetZone),
+	},
+	// Mon Jan _2 15:04:05 2006, May 8, 2009, Jan  2 15:04:05, Mon 2006-01-02 15:04:05 MST
+	StateAlphaWS: {
+		onLetter(StateAlphaWSAlpha),
+		onDigit(StateAlphaWSDigit),
+	},
+	StateAlphaWSDigit: {
+		on(",", StateAlphaWSDigitComma).final(),
+		on(":", StateAlphaWSDigitColon).final(),
+		on("-", StateAlphaWSDigitDash).final(),
+	},
+	// Mon Jan 02 15:04:05 -0700 2006, Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)
+	StateAlphaWSAlpha: {
+		on(":", StateAlphaWSAlphaColon),
+	},
+	StateAlphaWSAlphaColon: {
+		onLetter(StateAlphaWSAlphaColonAlpha),
+		on("-+", StateAlphaWSAlphaColonOffset),
+	},
+	// Mon Jan _2 15:04:05 MST 2006, Mon Aug 10 15:44:11 UTC+0100 2015
+	StateAlphaWSAlphaColonAlpha: {
+		on("+", StateAlphaWSAlphaColonAlphaOffset),
+	},
+	StateAlphaWSAlphaColonAlphaOffset: {
+		onLetter(StateAlphaWSAlphaColonAlphaOffsetAlpha),
+	},
+}
+
+// letterBytes is unicode.IsLetter of every byte taken as a rune, as the
+// scan reads date strings a byte at a time.
+var letterBytes = func() (letters [256]bool) {
+	for c := range letters {
+		letters[c] = unicode.IsLetter(rune(c))
+	}
+	return letters
+}()
+
+func (t *transition) matches(c byte) bool {
+	return t.any || (t.digit && isDigit(c)) || (t.letter && letterBytes[c]) || strings.IndexByte(t.on, c) >= 0
+}
+
+// step is what a state does on a byte: the state it goes to, with flags
+// for a final edge, a target without edges, no matching edge, or a first
+// matching edge with a condition, which the scan resolves by walking edges.
+type step uint16
+
+const (
+	stepStop step = 1 << (8 + iota)
+	stepDead
+	stepNone
+	stepCond
+)
+
+// edgeStep is the step for t.
+func edgeStep(t *transition) step {
+	s := step(t.to)
+	if t.stop {
+		s |= stepStop
+	}
+	if int(t.to) >= len(transitions) || transitions[t.to] == nil {
+		s |= stepDead
+	}
+	return s
+}
+
+// byteClasses and steps hold the step of every state on every byte.  Bytes
+// all states treat alike share a class, which keeps steps small enough to
+// stay in the cache, so the scan reads two small entries per byte.
+var byteClasses, steps = func() (classes [256]uint8, steps [len(transitions)][maxClasses]step) {
+	var columns [][len(transitions)]step
+	for c := 0; c < 256; c++ {
+		var column [len(transitions)]step
+		for state, ts := range transitions {
+			column[state] = stepNone
+			for k := range ts {
+				if ts[k].matches(byte(c)) {
+					column[state] = edgeStep(&ts[k])
+					if ts[k].cond != nil {
+						column[state] = stepCond
+					}
+					break
+				}
+			}
+		}
+		class := 0
+		for class < len(columns) && columns[class] != column {
+			class++
+		}
+		if class == len(columns) {
+			columns = append(columns, column)
+		}
+		classes[c] = uint8(class)
+	}
+	for class, column := range columns {
+		for state := range column {
+			steps[state][class] = column[state]
+		}
+	}
+	return classes, steps
+}()
+
+// maxClasses bounds the byte classes, the transitions have 15.
+const maxClasses = 32
+
+// condStep is the step of state on datestr[i] when edges with conditions
+// match it.
+func condStep(state DateState, datestr string, i int) step {
+	c := datestr[i]
+	for k := range transitions[state] {
+		t := &transitions[state][k]
+		if t.matches(c) && (t.cond == nil || t.cond.ok(datestr, i)) {
+			return edgeStep(t)
+		}
+	}
+	return stepNone
+}
+
+// scan runs the automaton over datestr, recording its tokens.  It returns
+// the final state and whether it was stopped, by a final transition or a
+// state without edges, rather than by the end of datestr.
+//
+// General strategy is to read byte by byte through the date looking for
+// certain hints of what type of date we are dealing with.  Hopefully we
+// only need to read about 5 or 6 bytes before we figure it out and then
+// attempt a parse.
+func (p *parser) scan(datestr string) (DateState, bool) {
+	p.toks.split(datestr)
+	trace := p.trace != nil
+	if trace {
+		p.traceScan(datestr)
+	}
+	state, dead := StateStart, false
+	for i := 0; i < len(datestr); i++ {
+		if dead {
+			if trace {
+				p.traceStep(datestr, i, state, state, true)
+			}
+			return state, true
+		}
+		c := byteClasses[datestr[i]]
+		s := steps[state][c]
+		if (s == stepNone || s == step(state)) && !trace {
+			// the state stays for the rest of the bytes of the class
+			for i+1 < len(datestr) && byteClasses[datestr[i+1]] == c {
+				i++
+			}
+			continue
+		}
+		from, stop := state, false
+		if s == stepCond {
+			s = condStep(state, datestr, i)
+		}
+		if s&stepNone == 0 {
+			state, stop, dead = DateState(s&0xff), s&stepStop != 0, s&stepDead != 0
+		}
+		if trace {
+			p.traceStep(datestr, i, from, state, stop)
+		}
+		if stop {
+			return state, true
+		}
+	}
+	return state, false
+}
+
+// label describes the bytes and condition of t, such as `'-' '+'` or
+// `any if has "ago"`.
+func (t *transition) label() string {
+	var parts []string
+	for i := 0; i < len(t.on); i++ {
+		parts = append(parts, "'"+t.on[i:i+1]+"'")
+	}
+	if t.digit {
+		parts = append(parts, "digit")
+	}
+	if t.letter {
+		parts = append(parts, "letter")
+	}
+	if t.any {
+		parts = append(parts, "any")
+	}
+	label := strings.Join(parts, " ")
+	if t.cond != nil {
+		label += " if " + t.cond.name
+	}
+	return label
+}
+
+// WriteStateGraph writes the automaton ParseAny scans date strings with to
+// w as a Graphviz DOT graph.  Bold edges stop the scan, which is then
+// followed by the parse with the layouts of the final state:
+//
+//     dateparse.WriteStateGraph(f) // then dot -Tsvg states.dot > states.svg
+func WriteStateGraph(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph dateparse {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	for from, ts := range transitions {
+		for k := range ts {
+			t := &ts[k]
+			style := ""
+			if t.stop {
+				style = ", style=bold"
+			}
+			fmt.Fprintf(&b, "\t%s -> %s [label=%q%s];\n", DateState(from), t.to, t.label(), style)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,74 @@
+package dateparse
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestScanStates(t *testing.T) {
+	tests := []struct {
+		in      string
+		state   DateState
+		stopped bool
+	}{
+		{"1332151919", StateDigit, false},
+		{"1332151919.123456", StateDigitPeriod, true},
+		{"3:04 PM", StateDigitColon, true},
+		{"2014-04-26 05:24:37 PM", StateDigitDashWsWsAMPMMaybe, true},
+		{"2014-04-26 05:24:37 P", StateDigitDashWsWsAMPMMaybe, false},
+		{"2014-12-16 06:20:00 UTC", StateDigitDashWsWsAlpha, true},
+		{"2006-01-02T15:04:05+07:00", StateDigitDashTOffsetColon, true},
+		{"Monday, 02-Jan-06 15:04:05 MST", StateWeekdayComma, true},
+		{"Monday, 02 Jan 2006 15:04:05 -0700", StateWeekdayCommaOffset, true},
+		{"Mon, 02-Jan-06 15:04:05 MST", StateWeekdayAbbrevComma, true},
+		{"Mon, 02 Jan 2006 15:04:05 MST", StateWeekdayAbbrevComma, false},
+		{"Mon, 02 Jan 2006 15:04:05 -0700", StateWeekdayAbbrevCommaOffset, false},
+		{"3 days ago", StateHowLongAgo, true},
+		{"12 Feb 2006, 19:17", StateDigitAlpha, true},
+		{"02/Jan/2006:15:04:05 -0700", StateDigitSlashAlpha, true},
+		{"May 8, 2009 5:57:51 PM", StateAlphaWSDigitComma, true},
+	}
+	for _, tc := range tests {
+		p := &parser{}
+		state, stopped := p.scan(tc.in)
+		if state != tc.state || stopped != tc.stopped {
+			t.Errorf("%q: got %s %v want %s %v", tc.in, state, stopped, tc.state, tc.stopped)
+		}
+		// the tokens cover the whole string either way
+		if end := int(p.toks.list[p.toks.n-1].end); end != len(tc.in) {
+			t.Errorf("%q: tokens stop at %d", tc.in, end)
+		}
+	}
+}
+
+func TestWriteStateGraph(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteStateGraph(&b); err != nil {
+		t.Fatal(err)
+	}
+	dot := b.String()
+	if !strings.HasPrefix(dot, "digraph dateparse {\n") || !strings.HasSuffix(dot, "}\n") {
+		t.Fatalf("not a DOT graph:\n%s", dot)
+	}
+	for _, edge := range []string{
+		"\tStart -> Digit [label=\"digit\"];\n",
+		"\tDigit -> DigitColon [label=\"':' if i <= 2\", style=bold];\n",
+		"\tDigitDashWs -> DigitDashWsOffset [label=\"'-' '+'\"];\n",
+		"\tDigitAlpha -> HowLongAgo [label=\"any if has \\\"ago\\\"\", style=bold];\n",
+	} {
+		if !strings.Contains(dot, edge) {
+			t.Errorf("missing edge %q", edge)
+		}
+	}
+	if strings.Contains(dot, "DateState(") {
+		t.Errorf("unnamed state in graph:\n%s", dot)
+	}
+	edges := 0
+	for _, ts := range transitions {
+		edges += len(ts)
+	}
+	if n := strings.Count(dot, " -> "); n != edges {
+		t.Errorf("got %d edges want %d", n, edges)
+	}
+}
@@ -0,0 +1,91 @@
+package dateparse
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTwoDigitYear is returned for dates with a two digit year, such as
+// "02/03/06", when the parser was created with RejectTwoDigitYears.
+var ErrTwoDigitYear = errors.New("dateparse: two digit year")
+
+type yearPolicy int
+
+const (
+	// yearGo leaves the year as time.Parse reads it, 69-99 are 1969-1999
+	// and 00-68 are 2000-2068.
+	yearGo yearPolicy = iota
+	yearWindow
+	yearCentury
+	yearReject
+)
+
+// TwoDigitYearWindow reads two digit years as the year closest before, or
+// at most future years after, the year of the reference clock.  In 2020
+// TwoDigitYearWindow(20) reads 00-40 as 2000-2040 and 41-99 as 1941-1999,
+// TwoDigitYearWindow(0) never gives a year in the future which suits
+// birth dates.
+//
+// Without this option two digit years follow time.Parse: 69-99 are
+// 1969-1999 and 00-68 are 2000-2068.
+func TwoDigitYearWindow(future int) ParserOption {
+	return func(p *Parser) error {
+		if future < 0 || future > 99 {
+			return fmt.Errorf("two digit year window future %d not in 0-99", future)
+		}
+		p.yearPolicy, p.yearArg = yearWindow, future
+		return nil
+	}
+}
+
+// TwoDigitYearCentury reads two digit years in a fixed century,
+// TwoDigitYearCentury(1900) reads "02/03/06" as 1906-02-03.
+func TwoDigitYearCentury(century int) ParserOption {
+	return func(p *Parser) error {
+		if century%100 != 0 {
+			return fmt.Errorf("century %d is not a multiple of 100", century)
+		}
+		p.yearPolicy, p.yearArg = yearCentury, century
+		return nil
+	}
+}
+
+// RejectTwoDigitYears fails dates with a two digit year with
+// ErrTwoDigitYear.
+func RejectTwoDigitYears() ParserOption {
+	return func(p *Parser) error {
+		p.yearPolicy = yearReject
+		return nil
+	}
+}
+
+// twoDigitYear moves t, parsed with layout, to the year the two digit year
+// policy reads it as.
+func (p *parser) twoDigitYear(layout string, t time.Time) (time.Time, error) {
+	if p.yearPolicy == yearGo || !layoutYear2(layout) {
+		return t, nil
+	}
+	yy := t.Year() % 100
+	var year int
+	switch p.yearPolicy {
+	case yearReject:
+		p.policyErr = fmt.Errorf("%w: %02d", ErrTwoDigitYear, yy)
+		return time.Time{}, p.policyErr
+	case yearCentury:
+		year = p.yearArg + yy
+	default:
+		top := p.now().Year() + p.yearArg
+		year = top - ((top-yy)%100+100)%100
+	}
+	if year == t.Year() {
+		return t, nil
+	}
+	month, day := t.Month(), t.Day()
+	if day > daysIn(year, month) {
+		// 02/29/00 in 1900
+		p.policyErr = fmt.Errorf("day out of range: %d-%02d-%02d", year, month, day)
+		return time.Time{}, p.policyErr
+	}
+	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()), nil
+}
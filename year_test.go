@@ -0,0 +1,61 @@
+package dateparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTwoDigitYears(t *testing.T) {
+	clock := ReferenceClock(func() time.Time { return time.Date(2020, 6, 15, 10, 30, 0, 0, time.UTC) })
+	tests := []struct {
+		opt ParserOption
+		in  string
+		out string
+	}{
+		{clock, "1/2/68", "2068-01-02"},
+		{clock, "1/2/69", "1969-01-02"},
+		{TwoDigitYearWindow(0), "1/2/21", "1921-01-02"},
+		{TwoDigitYearWindow(0), "1/2/20", "2020-01-02"},
+		{TwoDigitYearWindow(20), "1/2/40", "2040-01-02"},
+		{TwoDigitYearWindow(20), "1/2/41", "1941-01-02"},
+		{TwoDigitYearWindow(20), "Monday, 02-Jan-55 15:04:05 UTC", "1955-01-02"},
+		{TwoDigitYearCentury(1900), "1/2/06", "1906-01-02"},
+		{TwoDigitYearCentury(2100), "06/01/02", "2106-01-02"},
+	}
+	for _, tc := range tests {
+		p, err := NewParser(clock, tc.opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := p.ParseAny(tc.in)
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+		if got := res.Time.Format("2006-01-02"); got != tc.out {
+			t.Errorf("%q: got %s want %s", tc.in, got, tc.out)
+		}
+	}
+
+	p, err := NewParser(RejectTwoDigitYears())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, datestr := range []string{"1/2/06", "Monday, 02-Jan-06 15:04:05 MST"} {
+		res, err := p.ParseAny(datestr)
+		if !errors.Is(err, ErrTwoDigitYear) || !res.Time.IsZero() {
+			t.Errorf("%q: got %v, %v want the zero time and ErrTwoDigitYear", datestr, res.Time, err)
+		}
+	}
+	if _, err := p.ParseAny("1/2/2006"); err != nil {
+		t.Errorf("1/2/2006: %v", err)
+	}
+	p, err = NewParser(TwoDigitYearCentury(1900))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res, err := p.ParseAny("02/29/00"); err == nil || !res.Time.IsZero() {
+		t.Errorf("02/29/00: got %v, %v want the zero time and an error", res.Time, err)
+	}
+}